@@ -11,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/discovery"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
 	openapiclient "k8s.io/client-go/openapi"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/util/openapi"
@@ -22,6 +23,12 @@ type APIDocsOptions struct {
 	restMapper      meta.RESTMapper
 	openAPISchema   openapi.Resources
 	openAPIClient   openapiclient.Client
+	authClient      authorizationv1client.SelfSubjectRulesReviewsGetter
+	namespace       string
+	context         string
+	server          string
+	configFlags     *genericclioptions.ConfigFlags
+	path            string
 }
 
 func NewAPIDocsOptions(streams genericiooptions.IOStreams) *APIDocsOptions {
@@ -41,7 +48,9 @@ func NewCmdAPIDocs() *cobra.Command {
 		Use:   "kubectl apidocs",
 		Short: "API resources explained in a tree view format.",
 	}
+	cmd.Flags().StringVar(&o.path, "path", "", "Open a resource or a field by its path, e.g. pods.spec.containers")
 	kubeConfigFlags := defaultConfigFlags().WithWarningPrinter(o.IOStreams)
+	o.configFlags = kubeConfigFlags
 	flags := cmd.PersistentFlags()
 	kubeConfigFlags.AddFlags(flags)
 	matchVersionKubeConfigFlags := cmdutil.NewMatchVersionFlags(kubeConfigFlags)
@@ -61,6 +70,11 @@ func (o *APIDocsOptions) Run() error {
 		RestMapper:      o.restMapper,
 		OpenAPISchema:   o.openAPISchema,
 		OpenAPIClient:   o.openAPIClient,
+		AuthClient:      o.authClient,
+		Namespace:       o.namespace,
+		Context:         o.context,
+		Server:          o.server,
+		InitialPath:     o.path,
 	})
 	return err
 }
@@ -83,6 +97,28 @@ func (o *APIDocsOptions) Complete(f cmdutil.Factory, _ []string) error {
 	if err != nil {
 		return err
 	}
+	clientSet, err := f.KubernetesClientSet()
+	if err != nil {
+		return err
+	}
+	o.authClient = clientSet.AuthorizationV1()
+	o.namespace, _, err = f.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+	rawConfig, err := f.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return err
+	}
+	o.context = rawConfig.CurrentContext
+	if o.configFlags.Context != nil && *o.configFlags.Context != "" {
+		o.context = *o.configFlags.Context
+	}
+	restConfig, err := f.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	o.server = restConfig.Host
 	return nil
 }
 
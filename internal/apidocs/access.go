@@ -0,0 +1,120 @@
+package apidocs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+// ResourceAccess holds the resource rules of the current identity in a namespace,
+// as reported by the SelfSubjectRulesReview API.
+type ResourceAccess struct {
+	namespace  string
+	rules      []authorizationv1.ResourceRule
+	incomplete bool
+}
+
+func loadResourceAccess(
+	ctx context.Context,
+	authClient authorizationv1client.SelfSubjectRulesReviewsGetter,
+	namespace string,
+) (*ResourceAccess, error) {
+	review, err := authClient.SelfSubjectRulesReviews().Create(ctx, &authorizationv1.SelfSubjectRulesReview{
+		Spec: authorizationv1.SelfSubjectRulesReviewSpec{Namespace: namespace},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error reviewing access rules: %w", err)
+	}
+	return &ResourceAccess{
+		namespace:  namespace,
+		rules:      review.Status.ResourceRules,
+		incomplete: review.Status.Incomplete,
+	}, nil
+}
+
+// splitVerbs divides verbs supported by a resource into allowed and forbidden ones for the current identity.
+// When the review is incomplete (e.g. a webhook authorizer can't enumerate its rules), verbs without
+// a matching rule may still be allowed, so they're unknown rather than forbidden.
+func (a *ResourceAccess) splitVerbs(group, resource string, verbs []string) (allowed, forbidden, unknown []string) {
+	for _, verb := range verbs {
+		switch {
+		case a.isAllowed(group, resource, verb):
+			allowed = append(allowed, verb)
+		case a.incomplete:
+			unknown = append(unknown, verb)
+		default:
+			forbidden = append(forbidden, verb)
+		}
+	}
+	return allowed, forbidden, unknown
+}
+
+func (a *ResourceAccess) isAllowed(group, resource, verb string) bool {
+	for i := range a.rules {
+		rule := &a.rules[i]
+		// rules restricted to specific names do not grant access to the resource in general
+		if len(rule.ResourceNames) > 0 {
+			continue
+		}
+		if matchesRuleValue(rule.APIGroups, group) &&
+			matchesRuleValue(rule.Resources, resource) &&
+			matchesRuleValue(rule.Verbs, verb) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesRuleValue(values []string, value string) bool {
+	for _, v := range values {
+		if v == rbacv1.VerbAll || v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// unknownVerbsPrefix marks verbs, that the incomplete review says nothing about, e.g. '?delete,patch'
+const unknownVerbsPrefix = "?"
+
+// getAccessTags renders verbs as tview color tags: allowed and forbidden in the colors of the theme,
+// unknown ones in the color of annotations.
+func getAccessTags(allowed, forbidden, unknown []string) string {
+	var parts []string
+	if len(allowed) > 0 {
+		parts = append(parts, theme.colorize(theme.allowed, strings.Join(allowed, ",")))
+	}
+	if len(forbidden) > 0 {
+		parts = append(parts, theme.colorize(theme.forbidden, strings.Join(forbidden, ",")))
+	}
+	if len(unknown) > 0 {
+		parts = append(parts, theme.colorize(theme.annotation, unknownVerbsPrefix+strings.Join(unknown, ",")))
+	}
+	return strings.Join(parts, " ")
+}
+
+func getAccessDescription(data *TreeData) string {
+	if data.access == nil {
+		return ""
+	}
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("ACCESS (namespace: %s):\n", data.access.namespace))
+	if len(data.allowedVerbs) > 0 {
+		sb.WriteString(fmt.Sprintf("  allowed:   %s\n", theme.colorize(theme.allowed, strings.Join(data.allowedVerbs, ", "))))
+	}
+	if len(data.forbiddenVerbs) > 0 {
+		sb.WriteString(fmt.Sprintf("  forbidden: %s\n", theme.colorize(theme.forbidden, strings.Join(data.forbiddenVerbs, ", "))))
+	}
+	if len(data.unknownVerbs) > 0 {
+		sb.WriteString(fmt.Sprintf("  unknown:   %s\n", theme.colorize(theme.annotation, strings.Join(data.unknownVerbs, ", "))))
+	}
+	if data.access.incomplete {
+		sb.WriteString("  (the rules list may be incomplete, the authorizer could not enumerate all rules)\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,78 @@
+package apidocs
+
+import (
+	"strings"
+	"testing"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+)
+
+func TestResourceAccess_IsAllowed(t *testing.T) {
+	access := &ResourceAccess{rules: []authorizationv1.ResourceRule{
+		{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get", "list"}},
+		{APIGroups: []string{"apps"}, Resources: []string{"*"}, Verbs: []string{"watch"}},
+		{APIGroups: []string{"*"}, Resources: []string{"configmaps"}, Verbs: []string{"*"}},
+		{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}, ResourceNames: []string{"token"}},
+		{APIGroups: []string{""}, Resources: []string{"pods/log"}, Verbs: []string{"delete"}},
+	}}
+	tests := []struct {
+		name     string
+		group    string
+		resource string
+		verb     string
+		expected bool
+	}{
+		{name: "exact", group: "", resource: "pods", verb: "get", expected: true},
+		{name: "other verb", group: "", resource: "pods", verb: "delete", expected: false},
+		{name: "other group", group: "apps", resource: "pods", verb: "get", expected: false},
+		{name: "wildcard resource", group: "apps", resource: "deployments", verb: "watch", expected: true},
+		{name: "wildcard resource, other verb", group: "apps", resource: "deployments", verb: "get", expected: false},
+		{name: "wildcard group and verb", group: "batch", resource: "configmaps", verb: "patch", expected: true},
+		{name: "restricted to names", group: "", resource: "secrets", verb: "get", expected: false},
+		{name: "subresource does not grant the resource", group: "", resource: "pods", verb: "delete", expected: false},
+	}
+	for _, tt := range tests {
+		if actual := access.isAllowed(tt.group, tt.resource, tt.verb); actual != tt.expected {
+			t.Fatalf("%s: expected %v, got %v", tt.name, tt.expected, actual)
+		}
+	}
+}
+
+func TestResourceAccess_SplitVerbs(t *testing.T) {
+	rules := []authorizationv1.ResourceRule{
+		{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get", "list"}},
+	}
+	verbs := []string{"get", "list", "delete", "patch"}
+	tests := []struct {
+		name       string
+		incomplete bool
+		allowed    string
+		forbidden  string
+		unknown    string
+	}{
+		{name: "complete", incomplete: false, allowed: "get,list", forbidden: "delete,patch", unknown: ""},
+		{name: "incomplete", incomplete: true, allowed: "get,list", forbidden: "", unknown: "delete,patch"},
+	}
+	for _, tt := range tests {
+		access := &ResourceAccess{rules: rules, incomplete: tt.incomplete}
+		allowed, forbidden, unknown := access.splitVerbs("", "pods", verbs)
+		if strings.Join(allowed, ",") != tt.allowed ||
+			strings.Join(forbidden, ",") != tt.forbidden ||
+			strings.Join(unknown, ",") != tt.unknown {
+			t.Fatalf("%s: unexpected verbs: allowed=%v forbidden=%v unknown=%v", tt.name, allowed, forbidden, unknown)
+		}
+	}
+}
+
+func TestGetAccessTags(t *testing.T) {
+	theme = themes[themeMonochrome]
+	defer func() { theme = themes[themeDark] }()
+
+	tags := getAccessTags([]string{"get"}, []string{"delete"}, []string{"patch", "update"})
+	expected := theme.colorize(theme.allowed, "get") + " " +
+		theme.colorize(theme.forbidden, "delete") + " " +
+		theme.colorize(theme.annotation, "?patch,update")
+	if tags != expected {
+		t.Fatalf("Expected %q, got %q", expected, tags)
+	}
+}
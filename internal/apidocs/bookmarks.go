@@ -0,0 +1,123 @@
+package apidocs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const bookmarksFileName = "bookmarks.json"
+
+// Bookmark points to a resource or field node
+type Bookmark struct {
+	Group    string `json:"group"`
+	Version  string `json:"version"`
+	Resource string `json:"resource"`
+	Path     string `json:"path"`
+}
+
+func (b *Bookmark) GVR() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: b.Group, Version: b.Version, Resource: b.Resource}
+}
+
+func (b *Bookmark) String() string {
+	return fmt.Sprintf("%s  %s", b.GVR().GroupVersion().String(), b.Path)
+}
+
+// Bookmarks are persisted in a per-user state file
+type Bookmarks struct {
+	file  string
+	Items []Bookmark `json:"bookmarks"`
+
+	// loadErr is set, when the file exists, but can't be read, it's never overwritten then,
+	// so bookmarks aren't lost until the file is fixed or removed
+	loadErr error
+}
+
+// LoadBookmarks reads bookmarks from a file, a missing file means there are no bookmarks yet
+func LoadBookmarks(file string) (*Bookmarks, error) {
+	b := &Bookmarks{file: file}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return b, nil
+		}
+		b.loadErr = err
+		return b, err
+	}
+	if err := json.Unmarshal(content, b); err != nil {
+		b.Items = nil
+		b.loadErr = fmt.Errorf("error parsing bookmarks file %s: %w", file, err)
+		return b, b.loadErr
+	}
+	return b, nil
+}
+
+func (b *Bookmarks) IndexOf(gvr schema.GroupVersionResource, path string) int {
+	for i := range b.Items {
+		if b.Items[i].GVR() == gvr && b.Items[i].Path == path {
+			return i
+		}
+	}
+	return -1
+}
+
+// Toggle adds a bookmark, or removes it if it exists, and saves the file, returns whether the bookmark was added
+func (b *Bookmarks) Toggle(gvr schema.GroupVersionResource, path string) (bool, error) {
+	if err := b.checkWritable(); err != nil {
+		return false, err
+	}
+	if i := b.IndexOf(gvr, path); i >= 0 {
+		return false, b.Remove(i)
+	}
+	items := append(slices.Clone(b.Items), Bookmark{
+		Group:    gvr.Group,
+		Version:  gvr.Version,
+		Resource: gvr.Resource,
+		Path:     path,
+	})
+	return true, b.save(items)
+}
+
+func (b *Bookmarks) Remove(i int) error {
+	if err := b.checkWritable(); err != nil {
+		return err
+	}
+	return b.save(slices.Delete(slices.Clone(b.Items), i, i+1))
+}
+
+// checkWritable refuses changes, that can't be saved, before they're made
+func (b *Bookmarks) checkWritable() error {
+	if b.file == "" {
+		return fmt.Errorf("bookmarks file is not set")
+	}
+	if b.loadErr != nil {
+		return fmt.Errorf("bookmarks are not saved, fix or remove the file: %w", b.loadErr)
+	}
+	return nil
+}
+
+// save writes the changed list of bookmarks, it replaces the current one only when the file is written,
+// so the bookmarks shown are the ones, that are kept after a restart
+func (b *Bookmarks) save(items []Bookmark) error {
+	if err := b.checkWritable(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(b.file), 0o755); err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(&Bookmarks{Items: items}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(b.file, content, 0o600); err != nil {
+		return err
+	}
+	b.Items = items
+	return nil
+}
@@ -0,0 +1,116 @@
+package apidocs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestBookmarks_RoundTrip(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "state", bookmarksFileName)
+
+	// a missing file means there are no bookmarks yet
+	b, err := LoadBookmarks(file)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(b.Items) != 0 {
+		t.Fatalf("Expected no bookmarks, got %+v", b.Items)
+	}
+
+	podsGVR := testDeploymentGVR
+	podsGVR.Group, podsGVR.Resource = "", "pods"
+	for _, toggle := range []struct {
+		gvr  schema.GroupVersionResource
+		path string
+	}{{testDeploymentGVR, "deployments.spec.replicas"}, {podsGVR, "pods"}} {
+		if added, err := b.Toggle(toggle.gvr, toggle.path); err != nil || !added {
+			t.Fatalf("Expected %s to be added, got %v, %v", toggle.path, added, err)
+		}
+	}
+
+	loaded, err := LoadBookmarks(file)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(loaded.Items) != 2 || loaded.IndexOf(testDeploymentGVR, "deployments.spec.replicas") != 0 ||
+		loaded.IndexOf(podsGVR, "pods") != 1 {
+		t.Fatalf("Unexpected bookmarks: %+v", loaded.Items)
+	}
+
+	// toggling an existing bookmark removes it
+	if added, err := loaded.Toggle(podsGVR, "pods"); err != nil || added {
+		t.Fatalf("Expected pods to be removed, got %v, %v", added, err)
+	}
+	loaded, err = LoadBookmarks(file)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(loaded.Items) != 1 || loaded.Items[0].Path != "deployments.spec.replicas" {
+		t.Fatalf("Unexpected bookmarks: %+v", loaded.Items)
+	}
+}
+
+func TestBookmarks_FailedSave(t *testing.T) {
+	dir := t.TempDir()
+	b, err := LoadBookmarks(filepath.Join(dir, bookmarksFileName))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := b.Toggle(testDeploymentGVR, "deployments"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// the file can't be written, since its path is a directory now
+	if err := os.Remove(b.file); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(b.file, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Toggle(testDeploymentGVR, "deployments.spec"); err == nil {
+		t.Fatal("Expected adding to fail")
+	}
+	if err := b.Remove(0); err == nil {
+		t.Fatal("Expected removing to fail")
+	}
+	if len(b.Items) != 1 || b.Items[0].Path != "deployments" {
+		t.Fatalf("Expected bookmarks to be kept as saved, got %+v", b.Items)
+	}
+}
+
+func TestBookmarks_CorruptFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), bookmarksFileName)
+	corrupt := []byte(`{"bookmarks": [{"group": "apps",`)
+	if err := os.WriteFile(file, corrupt, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := LoadBookmarks(file)
+	if err == nil {
+		t.Fatal("Expected an error for a corrupt file")
+	}
+	if b == nil || len(b.Items) != 0 {
+		t.Fatalf("Expected empty bookmarks, got %+v", b)
+	}
+
+	// changes are refused, so the file is not overwritten
+	if _, err := b.Toggle(testDeploymentGVR, "deployments"); err == nil {
+		t.Fatal("Expected toggling to fail after a failed load")
+	}
+	if len(b.Items) != 0 {
+		t.Fatalf("Expected no bookmarks to be added, got %+v", b.Items)
+	}
+	if err := b.save(b.Items); err == nil {
+		t.Fatal("Expected saving to fail after a failed load")
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != string(corrupt) {
+		t.Fatalf("Expected the file to be kept, got %s", content)
+	}
+}
@@ -0,0 +1,311 @@
+package apidocs
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hashmap-kz/kubectl-apidocs/internal/version"
+)
+
+// command is an entry of the command line, e.g. ':goto deployments.spec.replicas'
+type command struct {
+	name string
+	// args describes arguments for the help, e.g. '<path>', optional ones are in brackets, e.g. '[name]'
+	args        string
+	description string
+	// complete returns candidates for the argument, they are filtered by the typed prefix afterward
+	complete func(uiState *UIState, arg string) []string
+	run      func(uiData *UIData, uiState *UIState, arg string) error
+}
+
+// CommandRegistry holds commands of the command line in the order they are listed in the help
+type CommandRegistry struct {
+	commands []*command
+}
+
+func NewCommandRegistry() *CommandRegistry {
+	return &CommandRegistry{commands: []*command{
+		{
+			name:        "q",
+			description: "Quit application",
+			run: func(_ *UIData, uiState *UIState, _ string) error {
+				uiState.app.Stop()
+				return nil
+			},
+		},
+		{
+			name:        "goto",
+			args:        "<path>",
+			description: "Jump to a resource or a field by its path, e.g. deployments.spec.replicas or po.spec.containers",
+			complete: func(uiState *UIState, arg string) []string {
+				return getPathCandidates(uiState.treeLinks.GetPaths(), arg)
+			},
+			run: runGoto,
+		},
+		{
+			name:        "export",
+			args:        "<file>",
+			description: "Write the details of the selected node to a file",
+			run:         runExport,
+		},
+		{
+			name:        "theme",
+			args:        "<name>",
+			description: "Switch the color theme: " + strings.Join(getThemeNames(), ", "),
+			complete: func(_ *UIState, _ string) []string {
+				return getThemeNames()
+			},
+			run: runTheme,
+		},
+		{
+			name:        "version",
+			description: "Show the version",
+			run: func(_ *UIData, uiState *UIState, _ string) error {
+				setStatus(uiState, fmt.Sprintf("kubectl-apidocs %s", version.Version))
+				return nil
+			},
+		},
+		{
+			name:        "ctx",
+			description: "Show the current context, cluster and namespace",
+			run: func(uiData *UIData, uiState *UIState, _ string) error {
+				setStatus(uiState, fmt.Sprintf("context: %s | cluster: %s | namespace: %s",
+					uiData.Context, uiData.Server, uiData.Namespace))
+				return nil
+			},
+		},
+		{
+			name:        "cel",
+			args:        "<text>",
+			description: "Show fields and resources with CEL validation rules containing a text",
+			run: func(_ *UIData, uiState *UIState, arg string) error {
+				recordLocation(uiState)
+				showCELRulesTree(uiState, uiState.apiResourcesTreeView, arg)
+				return nil
+			},
+		},
+		{
+			name:        "deprecated",
+			description: "Show deprecated fields and resources",
+			run: func(_ *UIData, uiState *UIState, _ string) error {
+				recordLocation(uiState)
+				showDeprecatedTree(uiState, uiState.apiResourcesTreeView)
+				return nil
+			},
+		},
+		{
+			name:        "usages",
+			args:        "[definition]",
+			description: "Show resources and fields using the definition of the selected node, or a definition, e.g. LabelSelector",
+			complete: func(uiState *UIState, _ string) []string {
+				return uiState.references.GetCandidates()
+			},
+			run: runUsages,
+		},
+		{
+			name:        "errors",
+			description: "List resources, that fields can't be loaded for, and jump to one of them",
+			run: func(uiData *UIData, uiState *UIState, _ string) error {
+				showErrors(uiData, uiState)
+				return nil
+			},
+		},
+		{
+			name:        "messages",
+			description: "Show status messages and errors with their timestamps",
+			run: func(_ *UIData, uiState *UIState, _ string) error {
+				showMessages(uiState)
+				return nil
+			},
+		},
+		{
+			name:        "marks",
+			description: "List bookmarks and jump to one of them",
+			run: func(uiData *UIData, uiState *UIState, _ string) error {
+				showBookmarks(uiData, uiState)
+				return nil
+			},
+		},
+		{
+			name:        "history",
+			description: "List visited nodes and jump to one of them",
+			run: func(uiData *UIData, uiState *UIState, _ string) error {
+				showHistory(uiData, uiState)
+				return nil
+			},
+		},
+		{
+			name:        "help",
+			description: "Show all keys and commands",
+			run: func(_ *UIData, uiState *UIState, _ string) error {
+				showHelp(uiState)
+				return nil
+			},
+		},
+	}}
+}
+
+func (r *CommandRegistry) Find(name string) *command {
+	for _, c := range r.commands {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func (r *CommandRegistry) Names() []string {
+	names := make([]string, 0, len(r.commands))
+	for _, c := range r.commands {
+		names = append(names, c.name)
+	}
+	return names
+}
+
+// Run executes a command line like 'goto deployments.spec'
+func (r *CommandRegistry) Run(uiData *UIData, uiState *UIState, line string) error {
+	name, arg, _ := strings.Cut(strings.TrimSpace(line), " ")
+	if name == "" {
+		return nil
+	}
+	c := r.Find(name)
+	if c == nil {
+		return fmt.Errorf("unknown command: %q, see :help", name)
+	}
+	arg = strings.TrimSpace(arg)
+	if strings.HasPrefix(c.args, "<") && arg == "" {
+		return fmt.Errorf("usage: :%s %s", c.name, c.args)
+	}
+	return c.run(uiData, uiState, arg)
+}
+
+// Complete completes a command line by TAB, see completeCommandLine
+func (r *CommandRegistry) Complete(uiState *UIState, line string) (string, []string) {
+	return completeCommandLine(line, r.Names(), func(name, arg string) []string {
+		if c := r.Find(name); c != nil && c.complete != nil {
+			return c.complete(uiState, arg)
+		}
+		return nil
+	})
+}
+
+// completeCommandLine completes the command name, or the argument when the name is followed by a space.
+// The line is extended up to the longest common prefix of the candidates,
+// which are returned as well, so they may be shown when there are several of them.
+func completeCommandLine(line string, names []string, getArgCandidates func(name, arg string) []string) (string, []string) {
+	name, arg, hasArg := strings.Cut(strings.TrimLeft(line, " "), " ")
+	if !hasArg {
+		candidates := filterByPrefix(names, name)
+		if len(candidates) == 1 {
+			return candidates[0] + " ", candidates
+		}
+		return longestCommonPrefix(candidates, name), candidates
+	}
+
+	arg = strings.TrimLeft(arg, " ")
+	candidates := filterByPrefix(getArgCandidates(name, arg), arg)
+	return name + " " + longestCommonPrefix(candidates, arg), candidates
+}
+
+func filterByPrefix(values []string, prefix string) []string {
+	var result []string
+	for _, v := range values {
+		if strings.HasPrefix(v, prefix) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// longestCommonPrefix returns the common prefix of the values, or the fallback when there are no values
+func longestCommonPrefix(values []string, fallback string) string {
+	if len(values) == 0 {
+		return fallback
+	}
+	prefix := values[0]
+	for _, v := range values[1:] {
+		for !strings.HasPrefix(v, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
+
+// getPathCandidates completes paths segment by segment: 'pods.sp' -> 'pods.spec', 'pods.spec.' -> 'pods.spec.containers', ...
+func getPathCandidates(paths []string, prefix string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, p := range paths {
+		if !strings.HasPrefix(p, prefix) {
+			continue
+		}
+		candidate := p
+		if i := strings.Index(p[len(prefix):], "."); i >= 0 {
+			candidate = p[:len(prefix)+i]
+		}
+		if candidate == "" || seen[candidate] {
+			continue
+		}
+		seen[candidate] = true
+		result = append(result, candidate)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func runGoto(uiData *UIData, uiState *UIState, path string) error {
+	node, findErr := findNodeForPath(uiData, uiState, path)
+	if node == nil {
+		return findErr
+	}
+	if err := navigateToNode(uiData, uiState, node); err != nil {
+		return err
+	}
+	return findErr
+}
+
+func runExport(_ *UIData, uiState *UIState, file string) error {
+	data, err := extractTreeData(uiState.apiResourcesTreeView.GetCurrentNode())
+	if err != nil {
+		return err
+	}
+	if !data.IsNodeType(nodeTypeResource, nodeTypeField) {
+		return fmt.Errorf("select a resource or a field to export its details")
+	}
+	content := uiState.apiResourcesDetailsView.GetText(true)
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		return err
+	}
+	setStatus(uiState, fmt.Sprintf("exported %s to %s", data.path, file))
+	return nil
+}
+
+func runUsages(_ *UIData, uiState *UIState, name string) error {
+	var err error
+	if name == "" {
+		name, err = getSelectedDefinition(uiState)
+	} else {
+		name, err = uiState.references.Find(name)
+	}
+	if err != nil {
+		return err
+	}
+	usages := uiState.references.Usages(name)
+	recordLocation(uiState)
+	showUsagesTree(uiState, uiState.apiResourcesTreeView, usages)
+	setStatus(uiState, fmt.Sprintf("%d usages of %s", len(usages), name))
+	return nil
+}
+
+func runTheme(uiData *UIData, uiState *UIState, name string) error {
+	t, err := getTheme(name)
+	if err != nil {
+		return err
+	}
+	theme = t
+	applyTheme(uiData, uiState)
+	setStatus(uiState, fmt.Sprintf("theme: %s", name))
+	return nil
+}
@@ -0,0 +1,62 @@
+package apidocs
+
+import (
+	"reflect"
+	"testing"
+)
+
+var testCommandNames = []string{"q", "goto", "export", "theme", "history", "help"}
+
+func testArgCandidates(name, arg string) []string {
+	if name == "goto" {
+		return getPathCandidates([]string{
+			"pods",
+			"pods.spec",
+			"pods.spec.containers",
+			"pods.spec.containers.name",
+			"pods.spec.serviceAccountName",
+			"pods.status",
+		}, arg)
+	}
+	return nil
+}
+
+func TestCompleteCommandLine_Names(t *testing.T) {
+	tests := []struct {
+		line       string
+		expected   string
+		candidates []string
+	}{
+		{line: "go", expected: "goto ", candidates: []string{"goto"}},
+		{line: "h", expected: "h", candidates: []string{"history", "help"}},
+		{line: "he", expected: "help ", candidates: []string{"help"}},
+		{line: "x", expected: "x", candidates: nil},
+	}
+	for _, tt := range tests {
+		line, candidates := completeCommandLine(tt.line, testCommandNames, testArgCandidates)
+		if line != tt.expected || !reflect.DeepEqual(candidates, tt.candidates) {
+			t.Fatalf("%q: expected %q %v, got %q %v", tt.line, tt.expected, tt.candidates, line, candidates)
+		}
+	}
+}
+
+func TestCompleteCommandLine_Paths(t *testing.T) {
+	tests := []struct {
+		line       string
+		expected   string
+		candidates []string
+	}{
+		{line: "goto po", expected: "goto pods", candidates: []string{"pods"}},
+		{line: "goto pods.", expected: "goto pods.s", candidates: []string{"pods.spec", "pods.status"}},
+		{line: "goto pods.spec.", expected: "goto pods.spec.", candidates: []string{"pods.spec.containers", "pods.spec.serviceAccountName"}},
+		{line: "goto pods.spec.c", expected: "goto pods.spec.containers", candidates: []string{"pods.spec.containers"}},
+		{line: "goto deployments", expected: "goto deployments", candidates: nil},
+		{line: "theme d", expected: "theme d", candidates: nil},
+	}
+	for _, tt := range tests {
+		line, candidates := completeCommandLine(tt.line, testCommandNames, testArgCandidates)
+		if line != tt.expected || !reflect.DeepEqual(candidates, tt.candidates) {
+			t.Fatalf("%q: expected %q %v, got %q %v", tt.line, tt.expected, tt.candidates, line, candidates)
+		}
+	}
+}
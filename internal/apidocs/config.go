@@ -0,0 +1,64 @@
+package apidocs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	appConfigDirName = "kubectl-apidocs"
+	configFileName   = "config.yaml"
+)
+
+// Config is read from ~/.config/kubectl-apidocs/config.yaml
+type Config struct {
+	// Keymap binds actions to keys, e.g. 'copy: y' or 'collapse: [h, left]'
+	Keymap map[string]KeyList `json:"keymap,omitempty"`
+	// Theme is one of: dark, light, solarized, high-contrast, monochrome
+	Theme string `json:"theme,omitempty"`
+	// HideHelpBar hides the help menu at the top, the keys are listed on the help page anyway
+	HideHelpBar bool `json:"hideHelpBar,omitempty"`
+	// Layout of the tree and the details: auto (stacked on narrow terminals), horizontal or vertical
+	Layout string `json:"layout,omitempty"`
+}
+
+// getConfigDir returns a directory for the config and state files: $XDG_CONFIG_HOME/kubectl-apidocs,
+// or ~/.config/kubectl-apidocs
+func getConfigDir() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, appConfigDirName), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", appConfigDirName), nil
+}
+
+// LoadConfig reads a config file, a missing file means defaults are used
+func LoadConfig(file string) (*Config, error) {
+	config := &Config{}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return config, nil
+		}
+		return nil, err
+	}
+	if err := yaml.UnmarshalStrict(content, config); err != nil {
+		return nil, fmt.Errorf("error parsing config file %s: %w", file, err)
+	}
+	return config, nil
+}
+
+func loadConfigFromConfigDir() (*Config, error) {
+	dir, err := getConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	return LoadConfig(filepath.Join(dir, configFileName))
+}
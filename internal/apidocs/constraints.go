@@ -0,0 +1,95 @@
+package apidocs
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+type schemaConstraint struct {
+	name  string
+	value string
+}
+
+// getSchemaConstraints collects validation constraints and x-kubernetes-* extensions of a field,
+// the declared schema wins over the resolved one, since it is more specific.
+func getSchemaConstraints(fs *FieldSchema) []schemaConstraint {
+	var result []schemaConstraint
+	seen := make(map[string]bool)
+	add := func(name, value string) {
+		if value == "" || seen[name] {
+			return
+		}
+		seen[name] = true
+		result = append(result, schemaConstraint{name: name, value: value})
+	}
+
+	for _, s := range []*spec.Schema{fs.Declared, fs.Resolved} {
+		if s == nil {
+			continue
+		}
+		if len(s.Enum) > 0 {
+			values := make([]string, 0, len(s.Enum))
+			for _, v := range s.Enum {
+				values = append(values, fmt.Sprint(v))
+			}
+			add("enum", strings.Join(values, ", "))
+		}
+		add("pattern", s.Pattern)
+		add("minimum", formatBound(s.Minimum, s.ExclusiveMinimum))
+		add("maximum", formatBound(s.Maximum, s.ExclusiveMaximum))
+		add("minLength", formatInt(s.MinLength))
+		add("maxLength", formatInt(s.MaxLength))
+		add("minItems", formatInt(s.MinItems))
+		add("maxItems", formatInt(s.MaxItems))
+		add("format", s.Format)
+		if s.Nullable {
+			add("nullable", "true")
+		}
+		for _, ext := range []string{
+			"x-kubernetes-int-or-string",
+			"x-kubernetes-preserve-unknown-fields",
+			"x-kubernetes-embedded-resource",
+		} {
+			if v, ok := s.Extensions[ext]; ok {
+				add(ext, fmt.Sprint(v))
+			}
+		}
+	}
+	return result
+}
+
+func formatBound(v *float64, exclusive bool) string {
+	if v == nil {
+		return ""
+	}
+	if exclusive {
+		return fmt.Sprintf("%v (exclusive)", *v)
+	}
+	return fmt.Sprintf("%v", *v)
+}
+
+func formatInt(v *int64) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d", *v)
+}
+
+func getConstraintsDescription(fs *FieldSchema) string {
+	constraints := getSchemaConstraints(fs)
+	if len(constraints) == 0 {
+		return ""
+	}
+	width := 0
+	for _, c := range constraints {
+		width = max(width, len(c.name))
+	}
+	sb := strings.Builder{}
+	sb.WriteString("CONSTRAINTS:\n")
+	for _, c := range constraints {
+		sb.WriteString(fmt.Sprintf("  %-*s  %s\n", width+1, c.name+":", c.value))
+	}
+	return sb.String()
+}
@@ -0,0 +1,196 @@
+package apidocs
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/rivo/tview"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// childField is a row of the fields table in the details
+type childField struct {
+	name     string
+	typeName string
+	required bool
+	// refName is a definition of the field, or of its elements, if any
+	refName string
+}
+
+// getRefName returns a name of the referenced definition: of '$ref', or of a single-element 'allOf' wrapper
+func getRefName(s *spec.Schema) string {
+	if ref := s.Ref.String(); ref != "" {
+		return strings.TrimPrefix(ref, componentsSchemasPrefix)
+	}
+	if len(s.AllOf) == 1 {
+		return getRefName(&s.AllOf[0])
+	}
+	return ""
+}
+
+// getElementRefName returns a name of the referenced definition of a field, or of elements of an array or a map
+func getElementRefName(s *spec.Schema) string {
+	for i := 0; i < maxReferenceDepth && s != nil; i++ {
+		if name := getRefName(s); name != "" {
+			return name
+		}
+		switch {
+		case s.Items != nil && s.Items.Schema != nil:
+			s = s.Items.Schema
+		case s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil:
+			s = s.AdditionalProperties.Schema
+		default:
+			return ""
+		}
+	}
+	return ""
+}
+
+// getShortRefName returns the last segment of a definition name: io.k8s.api.core.v1.PodSpec -> PodSpec
+func getShortRefName(refName string) string {
+	return refName[strings.LastIndex(refName, ".")+1:]
+}
+
+// getSchemaTypeName describes a type in the way kubectl explain does: 'Object', '[]Container', 'map[string]string'
+func getSchemaTypeName(s *spec.Schema) string {
+	if name := getRefName(s); name != "" {
+		return getShortRefName(name)
+	}
+	switch {
+	case s.Items != nil && s.Items.Schema != nil:
+		return "[]" + getSchemaTypeName(s.Items.Schema)
+	case s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil:
+		return "map[string]" + getSchemaTypeName(s.AdditionalProperties.Schema)
+	case s.Extensions["x-kubernetes-int-or-string"] == true:
+		return "IntOrString"
+	case len(s.Type) > 0 && s.Type[0] != "object":
+		return s.Type[0]
+	default:
+		return "Object"
+	}
+}
+
+// getChildFields lists fields of an object, or of elements of an array or a map
+func getChildFields(fs *FieldSchema) []childField {
+	parent := getElementSchema(fs.doc, fs.Resolved)
+	names := make([]string, 0, len(parent.Properties))
+	for name := range parent.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make([]childField, 0, len(names))
+	for _, name := range names {
+		prop := parent.Properties[name]
+		fields = append(fields, childField{
+			name:     name,
+			typeName: getSchemaTypeName(&prop),
+			required: slices.Contains(parent.Required, name),
+			refName:  getElementRefName(&prop),
+		})
+	}
+	return fields
+}
+
+func getSchemaDescription(fs *FieldSchema) string {
+	if fs.Declared != nil && fs.Declared.Description != "" {
+		return fs.Declared.Description
+	}
+	return fs.Resolved.Description
+}
+
+// detailsLinks collects type references of the details, they're rendered as regions 'link-<index>'
+type detailsLinks struct {
+	refNames []string
+}
+
+// add wraps a text into a region, when it refers to a definition, links may be nil
+func (l *detailsLinks) add(refName, text string) string {
+	if l == nil || refName == "" {
+		return text
+	}
+	l.refNames = append(l.refNames, refName)
+	return fmt.Sprintf(`["%s"]%s[""]`, getLinkRegion(len(l.refNames)-1), text)
+}
+
+func getLinkRegion(i int) string {
+	return fmt.Sprintf("link-%d", i)
+}
+
+func detailsLabel(name string) string {
+	return theme.colorize(theme.accent, fmt.Sprintf("%-12s", name))
+}
+
+// renderFieldDetails renders the details of a resource or a field from its schema, with tview color tags:
+// the kind and the path, the type, the description, and the table of child fields
+func renderFieldDetails(fs *FieldSchema, path string, links *detailsLinks) string {
+	sb := strings.Builder{}
+	sb.WriteString(detailsLabel("KIND:") + theme.colorize(theme.resource, fs.GVK.Kind) + "\n")
+	sb.WriteString(detailsLabel("VERSION:") + fs.GVK.GroupVersion().String() + "\n")
+	sb.WriteString(detailsLabel("FIELD:") + tview.Escape(path) + "\n")
+	sb.WriteString(renderSchemaBody(fs, links))
+	return sb.String()
+}
+
+// renderDefinitionDetails renders a definition, e.g. io.k8s.api.core.v1.PodSpec, or a field of it, when the path is set
+func renderDefinitionDetails(fs *FieldSchema, refName, path string, links *detailsLinks) string {
+	header := detailsLabel("DEFINITION:") + theme.colorize(theme.resource, tview.Escape(refName)) + "\n"
+	if path != "" {
+		header += detailsLabel("FIELD:") + tview.Escape(path) + "\n"
+	}
+	return header + renderSchemaBody(fs, links)
+}
+
+// renderSchemaBody renders the type line, the description, and the table of child fields
+func renderSchemaBody(fs *FieldSchema, links *detailsLinks) string {
+	sb := strings.Builder{}
+	typeName := theme.colorize(theme.group, tview.Escape("<"+getSchemaTypeName(fs.Declared)+">"))
+	typeLine := links.add(getElementRefName(fs.Declared), typeName)
+	if fs.RefName != "" {
+		typeLine += " " + theme.colorize(theme.annotation, tview.Escape(fs.RefName))
+	}
+	if fs.Required {
+		typeLine += " " + theme.colorize(theme.alert, "required")
+	}
+	sb.WriteString(detailsLabel("TYPE:") + typeLine + "\n")
+
+	if description := getSchemaDescription(fs); description != "" {
+		sb.WriteString("\n" + detailsLabel("DESCRIPTION:") + "\n")
+		for _, line := range strings.Split(description, "\n") {
+			sb.WriteString("    " + colorizeDescription(line) + "\n")
+		}
+	}
+
+	if fields := getChildFields(fs); len(fields) > 0 {
+		nameWidth, typeWidth := 0, 0
+		for _, f := range fields {
+			nameWidth = max(nameWidth, len(f.name))
+			typeWidth = max(typeWidth, len(f.typeName)+2)
+		}
+		sb.WriteString("\n" + detailsLabel("FIELDS:") + "\n")
+		for _, f := range fields {
+			typeName := "<" + f.typeName + ">"
+			padding := strings.Repeat(" ", typeWidth-len(typeName))
+			row := fmt.Sprintf("  %s  %s%s",
+				theme.colorize(theme.field, fmt.Sprintf("%-*s", nameWidth, f.name)),
+				links.add(f.refName, theme.colorize(theme.group, tview.Escape(typeName))),
+				padding,
+			)
+			if f.required {
+				row += "  " + theme.colorize(theme.alert, "required")
+			}
+			sb.WriteString(row + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// colorizeDescription escapes a line of a description, and highlights deprecation notes
+func colorizeDescription(line string) string {
+	escaped := tview.Escape(line)
+	return deprecatedRegexp.ReplaceAllStringFunc(escaped, func(word string) string {
+		return theme.colorize(theme.deprecated, word)
+	})
+}
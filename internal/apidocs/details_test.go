@@ -0,0 +1,104 @@
+package apidocs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rivo/tview"
+)
+
+func TestGetChildFields(t *testing.T) {
+	doc := loadTestOpenAPIDocument(t)
+
+	fs, err := resolveFieldSchema(newSchemaDocument(doc), testDeploymentGVK, []string{"spec"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []childField{
+		{name: "containers", typeName: "[]Container", required: true, refName: "io.k8s.api.core.v1.Container"},
+		{name: "replicas", typeName: "integer"},
+	}
+	fields := getChildFields(fs)
+	if len(fields) != len(expected) {
+		t.Fatalf("Expected %d fields, got %+v", len(expected), fields)
+	}
+	for i := range expected {
+		if fields[i] != expected[i] {
+			t.Fatalf("Expected %+v, got %+v", expected[i], fields[i])
+		}
+	}
+
+	// fields of array elements
+	fs, err = resolveFieldSchema(newSchemaDocument(doc), testDeploymentGVK, []string{"spec", "containers"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !fs.Required {
+		t.Fatal("Expected containers to be required")
+	}
+	if fields := getChildFields(fs); len(fields) != 2 || fields[0].name != "imagePullPolicy" {
+		t.Fatalf("Unexpected fields of containers: %+v", fields)
+	}
+}
+
+func TestRenderFieldDetails(t *testing.T) {
+	doc := loadTestOpenAPIDocument(t)
+
+	fs, err := resolveFieldSchema(newSchemaDocument(doc), testDeploymentGVK, []string{"spec"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	links := &detailsLinks{}
+	view := tview.NewTextView().SetDynamicColors(true).SetText(renderFieldDetails(fs, "deployments.spec", links))
+	text := view.GetText(true)
+	for _, expected := range []string{
+		"KIND:       Deployment",
+		"VERSION:    apps/v1",
+		"FIELD:      deployments.spec",
+		"TYPE:       <DeploymentSpec> io.k8s.api.apps.v1.DeploymentSpec",
+		"containers  <[]Container>  required",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Fatalf("Expected %q in:\n%s", expected, text)
+		}
+	}
+
+	// the type line and the array of containers refer to definitions
+	expectedLinks := []string{"io.k8s.api.apps.v1.DeploymentSpec", "io.k8s.api.core.v1.Container"}
+	if len(links.refNames) != len(expectedLinks) {
+		t.Fatalf("Expected links %v, got %v", expectedLinks, links.refNames)
+	}
+	for i := range expectedLinks {
+		if links.refNames[i] != expectedLinks[i] {
+			t.Fatalf("Expected links %v, got %v", expectedLinks, links.refNames)
+		}
+	}
+}
+
+func TestRenderDefinitionDetails(t *testing.T) {
+	doc := loadTestOpenAPIDocument(t)
+
+	fs, err := resolveDefinitionSchema(doc, testDeploymentGVK, "io.k8s.api.core.v1.Container")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	links := &detailsLinks{}
+	view := tview.NewTextView().SetDynamicColors(true).SetText(renderDefinitionDetails(fs, "io.k8s.api.core.v1.Container", "", links))
+	text := view.GetText(true)
+	for _, expected := range []string{
+		"DEFINITION: io.k8s.api.core.v1.Container",
+		"TYPE:       <Object>",
+		"imagePullPolicy  <string>",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Fatalf("Expected %q in:\n%s", expected, text)
+		}
+	}
+	if len(links.refNames) != 0 {
+		t.Fatalf("Unexpected links: %v", links.refNames)
+	}
+
+	if _, err := resolveDefinitionSchema(doc, testDeploymentGVK, "io.k8s.api.core.v1.Unknown"); err == nil {
+		t.Fatalf("Expected an error for an unknown definition")
+	}
+}
@@ -0,0 +1,124 @@
+package apidocs
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// deprecatedRegexp matches descriptions like 'Deprecated: use X instead', or '... Deprecated in v1.25'
+var deprecatedRegexp = regexp.MustCompile(`\bDeprecated\b`)
+
+// FieldMeta holds properties of a field collected while visiting a resource schema
+type FieldMeta struct {
+	isArray bool
+	isMap   bool
+
+	// arrays and maps: how the collection merges under strategic merge patch and server-side apply
+	listType      string
+	listMapKeys   []string
+	mapType       string
+	patchMergeKey string
+	patchStrategy string
+
+	// the description says the field (or resource) is deprecated
+	deprecated bool
+
+	// a name of the OpenAPI definition of the field, or of its elements, e.g. io.k8s.api.core.v1.Container
+	definition string
+	// a name of the definition, that recurs at the field, it's visited once more on demand, see expandRecursion
+	recursion string
+}
+
+func isDeprecatedDescription(description string) bool {
+	return deprecatedRegexp.MatchString(description)
+}
+
+func (m *FieldMeta) isDeprecated() bool {
+	return m != nil && m.deprecated
+}
+
+func (m *FieldMeta) getDefinition() string {
+	if m == nil {
+		return ""
+	}
+	return m.definition
+}
+
+func (m *FieldMeta) getRecursion() string {
+	if m == nil {
+		return ""
+	}
+	return m.recursion
+}
+
+func (m *FieldMeta) isCollection() bool {
+	return m != nil && (m.isArray || m.isMap)
+}
+
+func (m *FieldMeta) setCollectionExtensions(extensions map[string]interface{}) {
+	m.listType, _ = extensions["x-kubernetes-list-type"].(string)
+	m.mapType, _ = extensions["x-kubernetes-map-type"].(string)
+	m.patchMergeKey, _ = extensions["x-kubernetes-patch-merge-key"].(string)
+	m.patchStrategy, _ = extensions["x-kubernetes-patch-strategy"].(string)
+	if keys, ok := extensions["x-kubernetes-list-map-keys"].([]interface{}); ok {
+		for _, k := range keys {
+			if s, ok := k.(string); ok {
+				m.listMapKeys = append(m.listMapKeys, s)
+			}
+		}
+	}
+}
+
+// getCollectionAnnotation returns a compact form of merge metadata for a tree node, e.g. {list=map(name) patch=merge(name)}
+func (m *FieldMeta) getCollectionAnnotation() string {
+	if m == nil {
+		return ""
+	}
+	var parts []string
+	if m.listType != "" {
+		if len(m.listMapKeys) > 0 {
+			parts = append(parts, fmt.Sprintf("list=%s(%s)", m.listType, strings.Join(m.listMapKeys, ",")))
+		} else {
+			parts = append(parts, "list="+m.listType)
+		}
+	}
+	if m.mapType != "" {
+		parts = append(parts, "map="+m.mapType)
+	}
+	if m.patchStrategy != "" {
+		if m.patchMergeKey != "" {
+			parts = append(parts, fmt.Sprintf("patch=%s(%s)", m.patchStrategy, m.patchMergeKey))
+		} else {
+			parts = append(parts, "patch="+m.patchStrategy)
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("{%s}", strings.Join(parts, " "))
+}
+
+func (m *FieldMeta) getCollectionDescription() string {
+	if m == nil || (m.listType == "" && m.mapType == "" && m.patchStrategy == "" && m.patchMergeKey == "") {
+		return ""
+	}
+	sb := strings.Builder{}
+	sb.WriteString("MERGE STRATEGY:\n")
+	if m.listType != "" {
+		sb.WriteString(fmt.Sprintf("  x-kubernetes-list-type:       %s\n", m.listType))
+	}
+	if len(m.listMapKeys) > 0 {
+		sb.WriteString(fmt.Sprintf("  x-kubernetes-list-map-keys:   %s\n", strings.Join(m.listMapKeys, ", ")))
+	}
+	if m.mapType != "" {
+		sb.WriteString(fmt.Sprintf("  x-kubernetes-map-type:        %s\n", m.mapType))
+	}
+	if m.patchStrategy != "" {
+		sb.WriteString(fmt.Sprintf("  x-kubernetes-patch-strategy:  %s\n", m.patchStrategy))
+	}
+	if m.patchMergeKey != "" {
+		sb.WriteString(fmt.Sprintf("  x-kubernetes-patch-merge-key: %s\n", m.patchMergeKey))
+	}
+	return sb.String()
+}
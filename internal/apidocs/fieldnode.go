@@ -15,6 +15,18 @@ func NewResourceFieldsNode() *ResourceFieldsNode {
 	}
 }
 
+// Find returns a node by its path, e.g. 'sts.spec.template', nil if there is none
+func (node *ResourceFieldsNode) Find(path string) *ResourceFieldsNode {
+	current := node
+	for _, part := range strings.Split(path, ".") {
+		current = current.Children[part]
+		if current == nil {
+			return nil
+		}
+	}
+	return current
+}
+
 func (node *ResourceFieldsNode) AddPath(path string) {
 	if strings.TrimSpace(path) == "" {
 		return
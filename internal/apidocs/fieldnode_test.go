@@ -153,3 +153,16 @@ func TestAddPath_EmptyPath(t *testing.T) {
 		t.Fatalf("Expected no children for empty path, got %d", len(node.Children))
 	}
 }
+
+func TestFind(t *testing.T) {
+	node := NewResourceFieldsNode()
+	node.AddPath("sts.spec.template")
+
+	found := node.Find("sts.spec")
+	if found == nil || found.Path != "sts.spec" {
+		t.Fatalf("Expected node 'sts.spec', got %+v", found)
+	}
+	if node.Find("sts.status") != nil {
+		t.Fatal("Expected no node for a missing path")
+	}
+}
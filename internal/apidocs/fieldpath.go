@@ -0,0 +1,168 @@
+package apidocs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// fieldPathSegment is a field name in a path, and the kind of collection the field holds
+type fieldPathSegment struct {
+	name    string
+	isArray bool
+	isMap   bool
+}
+
+// getFieldPathSegments splits a node path into segments (without the resource name),
+// collection kinds are taken from the nodes of parent fields
+func getFieldPathSegments(treeLinks *TreeLinks, data *TreeData) []fieldPathSegment {
+	if data.gvr == nil {
+		return nil
+	}
+	parts := strings.Split(data.path, ".")
+	segments := make([]fieldPathSegment, 0, len(parts))
+	for i := 1; i < len(parts); i++ {
+		segment := fieldPathSegment{name: parts[i]}
+		if node := treeLinks.FindNode(*data.gvr, strings.Join(parts[:i+1], ".")); node != nil {
+			if nodeData, err := extractTreeData(node); err == nil && nodeData.meta != nil {
+				segment.isArray = nodeData.meta.isArray
+				segment.isMap = nodeData.meta.isMap
+			}
+		}
+		segments = append(segments, segment)
+	}
+	return segments
+}
+
+func getDottedPath(segments []fieldPathSegment) string {
+	names := make([]string, 0, len(segments))
+	for _, s := range segments {
+		names = append(names, s.name)
+	}
+	return strings.Join(names, ".")
+}
+
+// getJSONPath renders a kubectl JSONPath expression, e.g. {.spec.containers[*].image},
+// collections are expanded for every segment but the last one
+func getJSONPath(segments []fieldPathSegment) string {
+	sb := strings.Builder{}
+	for i, s := range segments {
+		sb.WriteString(".")
+		sb.WriteString(s.name)
+		if i == len(segments)-1 {
+			break
+		}
+		if s.isArray {
+			sb.WriteString("[*]")
+		} else if s.isMap {
+			sb.WriteString(".*")
+		}
+	}
+	if sb.Len() == 0 {
+		return "{.}"
+	}
+	return fmt.Sprintf("{%s}", sb.String())
+}
+
+// jsonPathBody returns a JSONPath expression without braces, to be appended to another one
+func jsonPathBody(segments []fieldPathSegment) string {
+	if len(segments) == 0 {
+		return ""
+	}
+	jsonPath := getJSONPath(segments)
+	return jsonPath[1 : len(jsonPath)-1]
+}
+
+// getJQPath renders a jq (and yq) path, e.g. .spec.containers[].image
+func getJQPath(segments []fieldPathSegment) string {
+	sb := strings.Builder{}
+	for i, s := range segments {
+		sb.WriteString(".")
+		sb.WriteString(s.name)
+		if i == len(segments)-1 {
+			break
+		}
+		if s.isArray || s.isMap {
+			sb.WriteString("[]")
+		}
+	}
+	if sb.Len() == 0 {
+		return "."
+	}
+	return sb.String()
+}
+
+// getCELExpression renders an expression for ValidatingAdmissionPolicy, e.g. object.spec.containers.map(c, c.image),
+// collections in the middle of the path are traversed with the map() macro
+func getCELExpression(segments []fieldPathSegment) string {
+	return buildCELExpression("object", segments, map[string]bool{})
+}
+
+func buildCELExpression(prefix string, segments []fieldPathSegment, usedVars map[string]bool) string {
+	expr := prefix
+	for i, s := range segments {
+		expr = expr + "." + s.name
+		if i == len(segments)-1 || (!s.isArray && !s.isMap) {
+			continue
+		}
+		v := getCELVariable(s.name, usedVars)
+		// map() over a map iterates keys, values are accessed by the key
+		item := v
+		if s.isMap {
+			item = fmt.Sprintf("%s[%s]", expr, v)
+		}
+		return fmt.Sprintf("%s.map(%s, %s)", expr, v, buildCELExpression(item, segments[i+1:], usedVars))
+	}
+	return expr
+}
+
+func getCELVariable(name string, usedVars map[string]bool) string {
+	v := strings.ToLower(name[:1])
+	for i := 2; usedVars[v]; i++ {
+		v = fmt.Sprintf("%s%d", strings.ToLower(name[:1]), i)
+	}
+	usedVars[v] = true
+	return v
+}
+
+// getCustomColumn renders a column for '-o custom-columns', e.g. IMAGE:.spec.containers[*].image
+func getCustomColumn(segments []fieldPathSegment) string {
+	if len(segments) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s:%s", strings.ToUpper(segments[len(segments)-1].name), jsonPathBody(segments))
+}
+
+// supportedFieldSelectors lists resource specific fields, that may be used with '--field-selector'
+var supportedFieldSelectors = map[string][]string{
+	"pods": {
+		"spec.nodeName", "spec.restartPolicy", "spec.schedulerName", "spec.serviceAccountName",
+		"spec.hostNetwork", "status.phase", "status.podIP", "status.podIPs", "status.nominatedNodeName",
+	},
+	"events": {
+		"involvedObject.kind", "involvedObject.namespace", "involvedObject.name", "involvedObject.uid",
+		"involvedObject.apiVersion", "involvedObject.resourceVersion", "involvedObject.fieldPath",
+		"reason", "reportingComponent", "source", "type",
+	},
+	"secrets":                    {"type"},
+	"namespaces":                 {"status.phase"},
+	"nodes":                      {"spec.unschedulable"},
+	"replicasets":                {"status.replicas"},
+	"replicationcontrollers":     {"status.replicas"},
+	"jobs":                       {"status.successful"},
+	"certificatesigningrequests": {"spec.signerName"},
+	"services":                   {"spec.clusterIP", "spec.type"},
+}
+
+// getFieldSelector returns a '--field-selector' for a field, if the API server supports selecting by it
+func getFieldSelector(resource string, segments []fieldPathSegment) (string, bool) {
+	path := getDottedPath(segments)
+	if path == "metadata.name" || path == "metadata.namespace" {
+		return path + "=", true
+	}
+	for _, field := range supportedFieldSelectors[resource] {
+		if field == path {
+			return path + "=", true
+		}
+	}
+	return "", false
+}
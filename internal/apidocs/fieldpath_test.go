@@ -0,0 +1,90 @@
+package apidocs
+
+import "testing"
+
+var testContainerImageSegments = []fieldPathSegment{
+	{name: "spec"},
+	{name: "template"},
+	{name: "spec"},
+	{name: "containers", isArray: true},
+	{name: "image"},
+}
+
+func TestGetJSONPath(t *testing.T) {
+	tests := []struct {
+		segments []fieldPathSegment
+		expected string
+	}{
+		{segments: nil, expected: "{.}"},
+		{segments: []fieldPathSegment{{name: "spec"}, {name: "replicas"}}, expected: "{.spec.replicas}"},
+		{segments: testContainerImageSegments, expected: "{.spec.template.spec.containers[*].image}"},
+		{segments: []fieldPathSegment{{name: "spec"}, {name: "containers", isArray: true}}, expected: "{.spec.containers}"},
+		{segments: []fieldPathSegment{{name: "data", isMap: true}, {name: "name"}}, expected: "{.data.*.name}"},
+	}
+	for _, tt := range tests {
+		if actual := getJSONPath(tt.segments); actual != tt.expected {
+			t.Fatalf("Expected %s, got %s", tt.expected, actual)
+		}
+	}
+}
+
+func TestGetJQPath(t *testing.T) {
+	if actual := getJQPath(testContainerImageSegments); actual != ".spec.template.spec.containers[].image" {
+		t.Fatalf("Unexpected jq path: %s", actual)
+	}
+	if actual := getJQPath(nil); actual != "." {
+		t.Fatalf("Unexpected jq path for resource: %s", actual)
+	}
+}
+
+func TestGetCELExpression(t *testing.T) {
+	tests := []struct {
+		segments []fieldPathSegment
+		expected string
+	}{
+		{
+			segments: []fieldPathSegment{{name: "spec"}, {name: "replicas"}},
+			expected: "object.spec.replicas",
+		},
+		{
+			segments: testContainerImageSegments,
+			expected: "object.spec.template.spec.containers.map(c, c.image)",
+		},
+		{
+			segments: []fieldPathSegment{
+				{name: "spec"},
+				{name: "containers", isArray: true},
+				{name: "command", isArray: true},
+				{name: "ports", isArray: true},
+			},
+			expected: "object.spec.containers.map(c, c.command.map(c2, c2.ports))",
+		},
+		{
+			segments: []fieldPathSegment{{name: "spec"}, {name: "items", isMap: true}, {name: "value"}},
+			expected: "object.spec.items.map(i, object.spec.items[i].value)",
+		},
+	}
+	for _, tt := range tests {
+		if actual := getCELExpression(tt.segments); actual != tt.expected {
+			t.Fatalf("Expected %s, got %s", tt.expected, actual)
+		}
+	}
+}
+
+func TestGetCustomColumn(t *testing.T) {
+	if actual := getCustomColumn(testContainerImageSegments); actual != "IMAGE:.spec.template.spec.containers[*].image" {
+		t.Fatalf("Unexpected custom column: %s", actual)
+	}
+}
+
+func TestGetFieldSelector(t *testing.T) {
+	if selector, ok := getFieldSelector("pods", []fieldPathSegment{{name: "status"}, {name: "phase"}}); !ok || selector != "status.phase=" {
+		t.Fatalf("Expected status.phase selector for pods, got %q", selector)
+	}
+	if _, ok := getFieldSelector("deployments", []fieldPathSegment{{name: "metadata"}, {name: "name"}}); !ok {
+		t.Fatal("Expected metadata.name selector to be supported for any resource")
+	}
+	if _, ok := getFieldSelector("deployments", []fieldPathSegment{{name: "spec"}, {name: "replicas"}}); ok {
+		t.Fatal("Expected spec.replicas selector to be unsupported for deployments")
+	}
+}
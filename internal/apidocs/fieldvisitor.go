@@ -4,6 +4,7 @@ import (
 	"sort"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/kube-openapi/pkg/util/proto"
 	"k8s.io/kubectl/pkg/explain"
 )
@@ -13,6 +14,9 @@ type schemaVisitor struct {
 	pathSchema        map[string]proto.Schema
 	err               error
 	visitedReferences map[string]struct{}
+	fieldMetas        map[string]*FieldMeta
+	gvr               schema.GroupVersionResource
+	references        *ReferenceIndex
 }
 
 var _ proto.SchemaVisitor = (*schemaVisitor)(nil)
@@ -30,13 +34,22 @@ func (v *schemaVisitor) VisitKind(k *proto.Kind) {
 			return
 		}
 		v.pathSchema[paths[i]] = schema
+		if isDeprecatedDescription(schema.GetDescription()) {
+			v.getFieldMeta(paths[i]).deprecated = true
+		}
 		v.prevPath = paths[i]
 		schema.Accept(v)
 	}
 }
 
 func (v *schemaVisitor) VisitReference(r proto.Reference) {
+	v.references.add(r.Reference(), TypeUsage{GVR: v.gvr, Path: v.prevPath}, r.SubSchema())
+	if meta := v.getFieldMeta(v.prevPath); meta.definition == "" {
+		meta.definition = r.Reference()
+	}
+	// a recursive type is not visited again, the field is marked instead
 	if _, ok := v.visitedReferences[r.Reference()]; ok {
+		v.getFieldMeta(v.prevPath).recursion = r.Reference()
 		return
 	}
 	v.visitedReferences[r.Reference()] = struct{}{}
@@ -48,14 +61,33 @@ func (*schemaVisitor) VisitPrimitive(*proto.Primitive) {
 	// Nothing to do.
 }
 
+// VisitArray and VisitMap describe the outermost collection of a field, nested ones (e.g. map[string][]string)
+// are visited with the same path, and they're not recorded
 func (v *schemaVisitor) VisitArray(a *proto.Array) {
+	if fieldMeta := v.getFieldMeta(v.prevPath); !fieldMeta.isCollection() {
+		fieldMeta.isArray = true
+		fieldMeta.setCollectionExtensions(a.GetExtensions())
+	}
 	a.SubType.Accept(v)
 }
 
 func (v *schemaVisitor) VisitMap(m *proto.Map) {
+	if fieldMeta := v.getFieldMeta(v.prevPath); !fieldMeta.isCollection() {
+		fieldMeta.isMap = true
+		fieldMeta.setCollectionExtensions(m.GetExtensions())
+	}
 	m.SubType.Accept(v)
 }
 
+func (v *schemaVisitor) getFieldMeta(path string) *FieldMeta {
+	meta, ok := v.fieldMetas[path]
+	if !ok {
+		meta = &FieldMeta{}
+		v.fieldMetas[path] = meta
+	}
+	return meta
+}
+
 func (v *schemaVisitor) getVisitedPaths() []string {
 	paths := make([]string, 0, len(v.pathSchema))
 	for path := range v.pathSchema {
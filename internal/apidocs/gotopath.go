@@ -0,0 +1,55 @@
+package apidocs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// resolvePath resolves the resource of a path like 'po.spec.containers' (short names, kinds and singular names
+// are expanded by the RESTMapper), and returns the path as it is stored in the tree: 'pods.spec.containers'
+func resolvePath(restMapper meta.RESTMapper, path string) (schema.GroupVersionResource, string, error) {
+	resource, fields, _ := strings.Cut(strings.Trim(path, ". "), ".")
+	if resource == "" {
+		return schema.GroupVersionResource{}, "", fmt.Errorf("empty path")
+	}
+	gvr, err := restMapper.ResourceFor(schema.GroupVersionResource{Resource: strings.ToLower(resource)})
+	if err != nil {
+		return schema.GroupVersionResource{}, "", fmt.Errorf("unknown resource %q: %w", resource, err)
+	}
+	if fields == "" {
+		return gvr, gvr.Resource, nil
+	}
+	return gvr, gvr.Resource + "." + fields, nil
+}
+
+// findNodeForPath finds a resource or field node for a path, when the field does not exist,
+// the closest existing ancestor is returned with an error, so the jump still gets close to it
+func findNodeForPath(uiData *UIData, uiState *UIState, path string) (*tview.TreeNode, error) {
+	gvr, resolved, err := resolvePath(uiData.RestMapper, path)
+	if err != nil {
+		return nil, err
+	}
+
+	find := func(p string) *tview.TreeNode {
+		if node := uiState.treeLinks.FindNode(gvr, p); node != nil {
+			return node
+		}
+		// the tree holds preferred versions, that may differ from the one of the RESTMapper
+		return uiState.treeLinks.FindNodeByPath(p)
+	}
+
+	if node := find(resolved); node != nil {
+		return node, nil
+	}
+	for p := resolved; strings.Contains(p, "."); {
+		p = p[:strings.LastIndex(p, ".")]
+		if node := find(p); node != nil {
+			return node, fmt.Errorf("field not found: %s, showing %s", resolved, p)
+		}
+	}
+	return nil, fmt.Errorf("path not found: %s", resolved)
+}
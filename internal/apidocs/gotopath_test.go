@@ -0,0 +1,45 @@
+package apidocs
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func newTestRESTMapper() meta.RESTMapper {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	return mapper
+}
+
+func TestResolvePath(t *testing.T) {
+	mapper := newTestRESTMapper()
+	tests := []struct {
+		path     string
+		resource string
+		expected string
+	}{
+		{path: "pods.spec.containers", resource: "pods", expected: "pods.spec.containers"},
+		{path: "pod.spec", resource: "pods", expected: "pods.spec"},
+		{path: "Deployment.spec.replicas", resource: "deployments", expected: "deployments.spec.replicas"},
+		{path: "deployments", resource: "deployments", expected: "deployments"},
+		{path: " deployments.spec. ", resource: "deployments", expected: "deployments.spec"},
+	}
+	for _, tt := range tests {
+		gvr, path, err := resolvePath(mapper, tt.path)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tt.path, err)
+		}
+		if gvr.Resource != tt.resource || path != tt.expected {
+			t.Fatalf("%q: expected %s %s, got %s %s", tt.path, tt.resource, tt.expected, gvr.Resource, path)
+		}
+	}
+
+	for _, path := range []string{"", "widgets.spec"} {
+		if _, _, err := resolvePath(mapper, path); err == nil {
+			t.Fatalf("%q: expected error", path)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package apidocs
+
+import "github.com/rivo/tview"
+
+// History is a browser-like list of visited nodes
+type History struct {
+	entries []*tview.TreeNode
+	pos     int
+}
+
+func NewHistory() *History {
+	return &History{pos: -1}
+}
+
+// Visit records a jump: the node where the jump started, and the destination,
+// entries after the current position are dropped, as a browser does
+func (h *History) Visit(from, to *tview.TreeNode) {
+	h.entries = h.entries[:h.pos+1]
+	for _, n := range []*tview.TreeNode{from, to} {
+		if n != nil && (len(h.entries) == 0 || h.entries[len(h.entries)-1] != n) {
+			h.entries = append(h.entries, n)
+		}
+	}
+	h.pos = len(h.entries) - 1
+}
+
+func (h *History) Back() *tview.TreeNode {
+	if h.pos <= 0 {
+		return nil
+	}
+	h.pos--
+	return h.entries[h.pos]
+}
+
+func (h *History) Forward() *tview.TreeNode {
+	if h.pos >= len(h.entries)-1 {
+		return nil
+	}
+	h.pos++
+	return h.entries[h.pos]
+}
+
+// MoveTo sets the current position, used when an entry is chosen from the history list
+func (h *History) MoveTo(i int) *tview.TreeNode {
+	if i < 0 || i >= len(h.entries) {
+		return nil
+	}
+	h.pos = i
+	return h.entries[i]
+}
+
+func (h *History) Entries() []*tview.TreeNode {
+	return h.entries
+}
+
+func (h *History) Pos() int {
+	return h.pos
+}
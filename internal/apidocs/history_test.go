@@ -0,0 +1,60 @@
+package apidocs
+
+import (
+	"testing"
+
+	"github.com/rivo/tview"
+)
+
+func TestHistory_BackForward(t *testing.T) {
+	a, b, c := tview.NewTreeNode("a"), tview.NewTreeNode("b"), tview.NewTreeNode("c")
+	h := NewHistory()
+	h.Visit(a, b)
+	h.Visit(b, c)
+
+	if len(h.Entries()) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(h.Entries()))
+	}
+	if n := h.Back(); n != b {
+		t.Fatalf("Expected back to 'b', got %v", n)
+	}
+	if n := h.Back(); n != a {
+		t.Fatalf("Expected back to 'a', got %v", n)
+	}
+	if n := h.Back(); n != nil {
+		t.Fatalf("Expected no more entries back, got %v", n)
+	}
+	if n := h.Forward(); n != b {
+		t.Fatalf("Expected forward to 'b', got %v", n)
+	}
+}
+
+func TestHistory_VisitDropsForwardEntries(t *testing.T) {
+	a, b, c, d := tview.NewTreeNode("a"), tview.NewTreeNode("b"), tview.NewTreeNode("c"), tview.NewTreeNode("d")
+	h := NewHistory()
+	h.Visit(a, b)
+	h.Visit(b, c)
+	h.Back()
+	h.Visit(b, d)
+
+	entries := h.Entries()
+	if len(entries) != 3 || entries[2] != d {
+		t.Fatalf("Expected [a b d], got %d entries", len(entries))
+	}
+	if n := h.Forward(); n != nil {
+		t.Fatalf("Expected no forward entries, got %v", n)
+	}
+}
+
+func TestHistory_MoveTo(t *testing.T) {
+	a, b := tview.NewTreeNode("a"), tview.NewTreeNode("b")
+	h := NewHistory()
+	h.Visit(a, b)
+
+	if n := h.MoveTo(0); n != a || h.Pos() != 0 {
+		t.Fatalf("Expected to move to 'a', got %v", n)
+	}
+	if n := h.MoveTo(5); n != nil {
+		t.Fatalf("Expected nil for out of range index, got %v", n)
+	}
+}
@@ -0,0 +1,323 @@
+package apidocs
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// KeyAction is a name of an action, that may be bound to keys in the config file
+type KeyAction string
+
+const (
+	actionSearch         KeyAction = "search"
+	actionCommand        KeyAction = "command"
+	actionParent         KeyAction = "parent"
+	actionCollapse       KeyAction = "collapse"
+	actionExpand         KeyAction = "expand"
+	actionSwitchFocus    KeyAction = "switch-focus"
+	actionStepBack       KeyAction = "step-back"
+	actionCopy           KeyAction = "copy"
+	actionQuery          KeyAction = "query"
+	actionBookmark       KeyAction = "bookmark"
+	actionHistoryBack    KeyAction = "history-back"
+	actionHistoryForward KeyAction = "history-forward"
+	actionBreadcrumb     KeyAction = "breadcrumb"
+	actionHelp           KeyAction = "help"
+	actionGrowTree       KeyAction = "grow-tree"
+	actionShrinkTree     KeyAction = "shrink-tree"
+	actionToggleLayout   KeyAction = "toggle-layout"
+	actionZoom           KeyAction = "zoom"
+	actionExpandAll      KeyAction = "expand-all"
+	actionCollapseAll    KeyAction = "collapse-all"
+	actionNextLink       KeyAction = "next-link"
+	actionPrevLink       KeyAction = "prev-link"
+	actionFollowLink     KeyAction = "follow-link"
+
+	// keys of popups: the help page, the message log, and the bookmarks list
+	actionClose  KeyAction = "close"
+	actionRemove KeyAction = "remove"
+)
+
+// defaultKeyBindings are used for actions, that are not set in the config file
+var defaultKeyBindings = map[KeyAction][]string{
+	actionSearch:         {"/"},
+	actionCommand:        {":"},
+	actionParent:         {"b"},
+	actionCollapse:       {"h", "left"},
+	actionExpand:         {"l", "right"},
+	actionSwitchFocus:    {"tab"},
+	actionStepBack:       {"esc"},
+	actionCopy:           {"y"},
+	actionQuery:          {"Q"},
+	actionBookmark:       {"m"},
+	actionHistoryBack:    {"["},
+	actionHistoryForward: {"]"},
+	actionBreadcrumb:     {"B"},
+	actionHelp:           {"?"},
+	actionGrowTree:       {">"},
+	actionShrinkTree:     {"<"},
+	actionToggleLayout:   {"V"},
+	actionZoom:           {"f"},
+	actionExpandAll:      {"L", "zR"},
+	actionCollapseAll:    {"H", "zM"},
+	actionNextLink:       {"n"},
+	actionPrevLink:       {"N"},
+	actionFollowLink:     {"enter"},
+	actionClose:          {"q"},
+	actionRemove:         {"d"},
+}
+
+var namedKeys = map[string]tcell.Key{
+	"tab":       tcell.KeyTab,
+	"backtab":   tcell.KeyBacktab,
+	"esc":       tcell.KeyEscape,
+	"enter":     tcell.KeyEnter,
+	"backspace": tcell.KeyBackspace,
+	"delete":    tcell.KeyDelete,
+	"left":      tcell.KeyLeft,
+	"right":     tcell.KeyRight,
+	"up":        tcell.KeyUp,
+	"down":      tcell.KeyDown,
+	"home":      tcell.KeyHome,
+	"end":       tcell.KeyEnd,
+	"pgup":      tcell.KeyPgUp,
+	"pgdn":      tcell.KeyPgDn,
+}
+
+// KeyList is a list of keys in the config file, a single key may be set as a string
+type KeyList []string
+
+func (l *KeyList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*l = KeyList{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("a key binding must be a string or a list of strings: %s", string(data))
+	}
+	*l = list
+	return nil
+}
+
+// maxCount limits numeric prefixes, e.g. '3L'
+const maxCount = 99
+
+type keySpec struct {
+	name string
+	key  tcell.Key
+	r    rune
+	// chord is a sequence of two keys, e.g. 'zR', it's matched by the second key, see Keymap.Feed
+	chord string
+}
+
+func (s keySpec) matches(event *tcell.EventKey) bool {
+	if s.chord != "" {
+		return false
+	}
+	if s.key == tcell.KeyRune {
+		return event.Key() == tcell.KeyRune && event.Rune() == s.r
+	}
+	return event.Key() == s.key
+}
+
+// parseKeySpec parses keys like 'l', 'Q', 'tab', 'esc', 'left', 'ctrl-o', and chords like 'zR'
+func parseKeySpec(name string) (keySpec, error) {
+	if r := []rune(name); len(r) == 1 {
+		return keySpec{name: name, key: tcell.KeyRune, r: r[0]}, nil
+	}
+	lower := strings.ToLower(name)
+	if key, ok := namedKeys[lower]; ok {
+		return keySpec{name: lower, key: key}, nil
+	}
+	if letter, ok := strings.CutPrefix(lower, "ctrl-"); ok && len(letter) == 1 && letter[0] >= 'a' && letter[0] <= 'z' {
+		return keySpec{name: lower, key: tcell.KeyCtrlA + tcell.Key(letter[0]-'a')}, nil
+	}
+	if r := []rune(name); len(r) == 2 && isChordRune(r[0]) && isChordRune(r[1]) {
+		return keySpec{name: name, key: tcell.KeyRune, chord: name}, nil
+	}
+	return keySpec{}, fmt.Errorf("unknown key: %q", name)
+}
+
+func isChordRune(r rune) bool {
+	return r > ' ' && r < unicode.MaxASCII && (r < '0' || r > '9')
+}
+
+// Keymap binds actions to keys, and tracks numeric prefixes and chords, that are typed key by key
+type Keymap struct {
+	bindings map[KeyAction][]keySpec
+
+	// input in progress: a numeric prefix, and the first key of a chord
+	count   int
+	pending rune
+	// the chord completed by the last event, and the numeric prefix typed before the last event
+	completed string
+	lastCount int
+}
+
+// NewKeymap creates a keymap from the default bindings, overridden by the ones from the config file,
+// unknown actions, unknown keys, and keys bound to several actions are reported as errors
+func NewKeymap(overrides map[string]KeyList) (*Keymap, error) {
+	keys := make(map[KeyAction][]string, len(defaultKeyBindings))
+	for action, k := range defaultKeyBindings {
+		keys[action] = k
+	}
+	for action, k := range overrides {
+		if _, ok := defaultKeyBindings[KeyAction(action)]; !ok {
+			return nil, fmt.Errorf("keymap: unknown action: %q", action)
+		}
+		if len(k) == 0 {
+			return nil, fmt.Errorf("keymap: no keys for action: %q", action)
+		}
+		keys[KeyAction(action)] = k
+	}
+
+	keymap := &Keymap{bindings: make(map[KeyAction][]keySpec, len(keys))}
+	// keys are compared by the code and the rune, names may differ (e.g. 'Esc' and 'esc')
+	type keyID struct {
+		key   tcell.Key
+		r     rune
+		chord string
+	}
+	boundTo := make(map[keyID]KeyAction)
+	for _, action := range getSortedActions(keys) {
+		for _, name := range keys[action] {
+			spec, err := parseKeySpec(name)
+			if err != nil {
+				return nil, fmt.Errorf("keymap: action %q: %w", action, err)
+			}
+			id := keyID{key: spec.key, r: spec.r, chord: spec.chord}
+			if other, ok := boundTo[id]; ok {
+				return nil, fmt.Errorf("keymap: key %q is bound to both %q and %q", name, other, action)
+			}
+			boundTo[id] = action
+			keymap.bindings[action] = append(keymap.bindings[action], spec)
+		}
+	}
+	// a key, that starts a chord, would never be matched by itself
+	for id, action := range boundTo {
+		if id.chord == "" {
+			continue
+		}
+		first := []rune(id.chord)[0]
+		if other, ok := boundTo[keyID{key: tcell.KeyRune, r: first}]; ok {
+			return nil, fmt.Errorf("keymap: key %q of %q starts the chord %q of %q", string(first), other, id.chord, action)
+		}
+	}
+	return keymap, nil
+}
+
+func getSortedActions(keys map[KeyAction][]string) []KeyAction {
+	actions := make([]KeyAction, 0, len(keys))
+	for action := range keys {
+		actions = append(actions, action)
+	}
+	sort.Slice(actions, func(i, j int) bool {
+		return actions[i] < actions[j]
+	})
+	return actions
+}
+
+// Matches reports whether an event is bound to an action, an event that completes a chord matches the chord only
+func (k *Keymap) Matches(action KeyAction, event *tcell.EventKey) bool {
+	for _, spec := range k.bindings[action] {
+		if k.completed != "" {
+			if spec.chord == k.completed {
+				return true
+			}
+			continue
+		}
+		if spec.matches(event) {
+			return true
+		}
+	}
+	return false
+}
+
+// Feed tracks numeric prefixes and chords, it's called once for every key event, before Matches.
+// It returns true, when the event is consumed as a digit of a prefix, or as the first key of a chord.
+// An unknown chord is dropped.
+func (k *Keymap) Feed(event *tcell.EventKey) bool {
+	k.completed = ""
+	if event.Key() != tcell.KeyRune {
+		k.finishInput()
+		return false
+	}
+	r := event.Rune()
+
+	if k.pending != 0 {
+		chord := string([]rune{k.pending, r})
+		k.finishInput()
+		if k.isBoundChord(func(c string) bool { return c == chord }) {
+			k.completed = chord
+			return false
+		}
+		return true
+	}
+	if r >= '0' && r <= '9' && (r != '0' || k.count > 0) && !k.isBoundRune(r) {
+		k.count = min(k.count*10+int(r-'0'), maxCount)
+		return true
+	}
+	if k.isBoundChord(func(c string) bool { return []rune(c)[0] == r }) {
+		k.pending = r
+		return true
+	}
+	k.finishInput()
+	return false
+}
+
+// Reset drops the input in progress, e.g. when keys are typed into the command line
+func (k *Keymap) Reset() {
+	k.completed = ""
+	k.count = 0
+	k.pending = 0
+	k.lastCount = 0
+}
+
+// Count returns the numeric prefix typed before the last key, zero when there was none
+func (k *Keymap) Count() int {
+	return k.lastCount
+}
+
+func (k *Keymap) finishInput() {
+	k.lastCount = k.count
+	k.count = 0
+	k.pending = 0
+}
+
+func (k *Keymap) isBoundRune(r rune) bool {
+	for _, specs := range k.bindings {
+		for _, spec := range specs {
+			if spec.chord == "" && spec.key == tcell.KeyRune && spec.r == r {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (k *Keymap) isBoundChord(match func(chord string) bool) bool {
+	for _, specs := range k.bindings {
+		for _, spec := range specs {
+			if spec.chord != "" && match(spec.chord) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// KeysFor returns keys of an action for the help, e.g. 'h/left'
+func (k *Keymap) KeysFor(action KeyAction) string {
+	names := make([]string, 0, len(k.bindings[action]))
+	for _, spec := range k.bindings[action] {
+		names = append(names, spec.name)
+	}
+	return strings.Join(names, "/")
+}
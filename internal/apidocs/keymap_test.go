@@ -0,0 +1,120 @@
+package apidocs
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"sigs.k8s.io/yaml"
+)
+
+func TestParseKeySpec(t *testing.T) {
+	tests := []struct {
+		name string
+		key  tcell.Key
+		r    rune
+	}{
+		{name: "Q", key: tcell.KeyRune, r: 'Q'},
+		{name: "[", key: tcell.KeyRune, r: '['},
+		{name: "Tab", key: tcell.KeyTab},
+		{name: "left", key: tcell.KeyLeft},
+		{name: "ctrl-o", key: tcell.KeyCtrlO},
+	}
+	for _, tt := range tests {
+		spec, err := parseKeySpec(tt.name)
+		if err != nil {
+			t.Fatalf("Unexpected error for %q: %v", tt.name, err)
+		}
+		if spec.key != tt.key || spec.r != tt.r {
+			t.Fatalf("Unexpected key for %q: %+v", tt.name, spec)
+		}
+	}
+	spec, err := parseKeySpec("zR")
+	if err != nil || spec.chord != "zR" {
+		t.Fatalf("Expected a chord for 'zR', got %+v %v", spec, err)
+	}
+	for _, name := range []string{"", "ctrl-", "ctrl-1", "shift-a", "unknown", "z1"} {
+		if _, err := parseKeySpec(name); err == nil {
+			t.Fatalf("Expected error for %q", name)
+		}
+	}
+}
+
+func TestNewKeymap_Overrides(t *testing.T) {
+	config := &Config{}
+	content := "keymap:\n  copy: c\n  collapse: [J, left]\n"
+	if err := yaml.UnmarshalStrict([]byte(content), config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	keymap, err := NewKeymap(config.Keymap)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !keymap.Matches(actionCopy, tcell.NewEventKey(tcell.KeyRune, 'c', tcell.ModNone)) {
+		t.Fatal("Expected 'c' to be bound to copy")
+	}
+	if keymap.Matches(actionCopy, tcell.NewEventKey(tcell.KeyRune, 'y', tcell.ModNone)) {
+		t.Fatal("Expected 'y' to be unbound from copy")
+	}
+	if got := keymap.KeysFor(actionCollapse); got != "J/left" {
+		t.Fatalf("Unexpected keys for collapse: %s", got)
+	}
+	// defaults are kept for actions, that are not overridden
+	if got := keymap.KeysFor(actionQuery); got != "Q" {
+		t.Fatalf("Unexpected keys for query: %s", got)
+	}
+}
+
+func TestNewKeymap_Errors(t *testing.T) {
+	tests := map[string]map[string]KeyList{
+		"unknown action": {"fly": {"f"}},
+		"unknown key":    {"copy": {"hyper-c"}},
+		"no keys":        {"copy": {}},
+		"duplicate key":  {"copy": {"m"}},
+		"chord prefix":   {"copy": {"z"}},
+	}
+	for name, overrides := range tests {
+		if _, err := NewKeymap(overrides); err == nil {
+			t.Fatalf("Expected error for %s", name)
+		}
+	}
+}
+
+func feedRunes(keymap *Keymap, runes string) (consumed bool, event *tcell.EventKey) {
+	for _, r := range runes {
+		event = tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone)
+		consumed = keymap.Feed(event)
+	}
+	return consumed, event
+}
+
+func TestKeymap_ChordsAndCounts(t *testing.T) {
+	keymap, err := NewKeymap(nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// a chord is matched by its second key
+	if consumed, _ := feedRunes(keymap, "z"); !consumed {
+		t.Fatal("Expected the first key of a chord to be consumed")
+	}
+	if consumed, event := feedRunes(keymap, "R"); consumed || !keymap.Matches(actionExpandAll, event) {
+		t.Fatal("Expected 'zR' to match expand-all")
+	}
+	// an unknown chord is dropped
+	if consumed, _ := feedRunes(keymap, "zx"); !consumed {
+		t.Fatal("Expected an unknown chord to be consumed")
+	}
+
+	// a numeric prefix
+	if consumed, event := feedRunes(keymap, "12L"); consumed || !keymap.Matches(actionExpandAll, event) || keymap.Count() != 12 {
+		t.Fatalf("Expected '12L' to match expand-all with count 12, got %d", keymap.Count())
+	}
+	if consumed, event := feedRunes(keymap, "3zM"); consumed || !keymap.Matches(actionCollapseAll, event) || keymap.Count() != 3 {
+		t.Fatalf("Expected '3zM' to match collapse-all with count 3, got %d", keymap.Count())
+	}
+	// the count is reset by the next key
+	if _, event := feedRunes(keymap, "L"); !keymap.Matches(actionExpandAll, event) || keymap.Count() != 0 {
+		t.Fatalf("Expected no count, got %d", keymap.Count())
+	}
+}
@@ -1,21 +1,76 @@
 package apidocs
 
-import "github.com/rivo/tview"
+import (
+	"github.com/rivo/tview"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
 
 type TreeLinks struct {
 	// key=child, value=parent
 	ParentMap map[*tview.TreeNode]*tview.TreeNode
+	// key=gvr+path, value=resource or field node
+	PathMap map[string]*tview.TreeNode
+	// resource and field nodes in the tree order
+	Nodes []*tview.TreeNode
+	// key=definition name, value=definition node
+	DefinitionMap map[string]*tview.TreeNode
+	// resources, that fields can't be loaded for, in the tree order
+	ErrorNodes []*tview.TreeNode
 }
 
 func NewTreeLinks() *TreeLinks {
 	return &TreeLinks{
-		ParentMap: make(map[*tview.TreeNode]*tview.TreeNode),
+		ParentMap:     make(map[*tview.TreeNode]*tview.TreeNode),
+		PathMap:       make(map[string]*tview.TreeNode),
+		DefinitionMap: make(map[string]*tview.TreeNode),
 	}
 }
 
 func (t *TreeLinks) FillLinks(root *tview.TreeNode) {
+	if data, err := extractTreeData(root); err == nil {
+		if data.gvr != nil && data.IsNodeType(nodeTypeResource, nodeTypeField) {
+			t.PathMap[getPathKey(*data.gvr, data.path)] = root
+			t.Nodes = append(t.Nodes, root)
+		}
+		if data.IsNodeType(nodeTypeDefinition) {
+			t.DefinitionMap[data.definition] = root
+		}
+		if data.IsNodeType(nodeTypeError) {
+			t.ErrorNodes = append(t.ErrorNodes, root)
+		}
+	}
 	for _, c := range root.GetChildren() {
 		t.ParentMap[c] = root
 		t.FillLinks(c)
 	}
 }
+
+// FindNode returns a resource or field node by its path, e.g. 'deployments.spec.replicas'
+func (t *TreeLinks) FindNode(gvr schema.GroupVersionResource, path string) *tview.TreeNode {
+	return t.PathMap[getPathKey(gvr, path)]
+}
+
+// FindNodeByPath returns the first resource or field node with the path, when the resource is served by several groups
+func (t *TreeLinks) FindNodeByPath(path string) *tview.TreeNode {
+	for _, n := range t.Nodes {
+		if data, err := extractTreeData(n); err == nil && data.path == path {
+			return n
+		}
+	}
+	return nil
+}
+
+// GetPaths returns paths of all resources and fields, e.g. 'deployments.spec.replicas'
+func (t *TreeLinks) GetPaths() []string {
+	paths := make([]string, 0, len(t.Nodes))
+	for _, n := range t.Nodes {
+		if data, err := extractTreeData(n); err == nil {
+			paths = append(paths, data.path)
+		}
+	}
+	return paths
+}
+
+func getPathKey(gvr schema.GroupVersionResource, path string) string {
+	return gvr.String() + "|" + path
+}
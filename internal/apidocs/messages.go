@@ -0,0 +1,58 @@
+package apidocs
+
+import (
+	"fmt"
+	"time"
+)
+
+// maxMessages limits the message log, the oldest messages are dropped
+const maxMessages = 500
+
+// Message is an entry of the message log: a status message, or an error
+type Message struct {
+	Time    time.Time
+	Text    string
+	IsError bool
+	// Repeats counts consecutive identical messages, they're kept as a single entry with the latest time
+	Repeats int
+}
+
+func (m Message) String() string {
+	text := m.Text
+	if m.Repeats > 1 {
+		text = fmt.Sprintf("%s (x%d)", text, m.Repeats)
+	}
+	if m.IsError {
+		return fmt.Sprintf("%s error: %s", m.Time.Format(time.TimeOnly), text)
+	}
+	return fmt.Sprintf("%s %s", m.Time.Format(time.TimeOnly), text)
+}
+
+// MessageLog keeps messages of the status line, they're listed by ':messages'
+type MessageLog struct {
+	items []Message
+}
+
+func NewMessageLog() *MessageLog {
+	return &MessageLog{}
+}
+
+// Add appends a message, and returns it, a message repeating the last one is counted instead
+func (l *MessageLog) Add(now time.Time, text string, isError bool) Message {
+	if last := len(l.items) - 1; last >= 0 && l.items[last].Text == text && l.items[last].IsError == isError {
+		l.items[last].Time = now
+		l.items[last].Repeats++
+		return l.items[last]
+	}
+	m := Message{Time: now, Text: text, IsError: isError, Repeats: 1}
+	l.items = append(l.items, m)
+	if len(l.items) > maxMessages {
+		l.items = l.items[len(l.items)-maxMessages:]
+	}
+	return m
+}
+
+// Entries returns messages from the oldest one
+func (l *MessageLog) Entries() []Message {
+	return l.items
+}
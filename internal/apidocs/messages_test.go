@@ -0,0 +1,52 @@
+package apidocs
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestMessageLog(t *testing.T) {
+	now := time.Date(2024, 5, 1, 13, 4, 5, 0, time.UTC)
+	l := NewMessageLog()
+	l.Add(now, "theme: light", false)
+	m := l.Add(now, "no such path: pods.spec.x", true)
+
+	if m.String() != "13:04:05 error: no such path: pods.spec.x" {
+		t.Fatalf("Unexpected message: %q", m.String())
+	}
+	if entries := l.Entries(); len(entries) != 2 || entries[0].String() != "13:04:05 theme: light" {
+		t.Fatalf("Unexpected entries: %v", entries)
+	}
+}
+
+func TestMessageLog_Repeats(t *testing.T) {
+	now := time.Date(2024, 5, 1, 13, 4, 5, 0, time.UTC)
+	l := NewMessageLog()
+	l.Add(now, "explain failed", true)
+	l.Add(now.Add(time.Second), "explain failed", true)
+	m := l.Add(now.Add(2*time.Second), "explain failed", true)
+	if m.String() != "13:04:07 error: explain failed (x3)" {
+		t.Fatalf("Unexpected message: %q", m.String())
+	}
+	// the same text as a status is not an error repeat
+	l.Add(now, "explain failed", false)
+	l.Add(now, "explain failed", true)
+	if entries := l.Entries(); len(entries) != 3 || entries[0].Repeats != 3 || entries[2].Repeats != 1 {
+		t.Fatalf("Unexpected entries: %v", entries)
+	}
+}
+
+func TestMessageLog_Limit(t *testing.T) {
+	l := NewMessageLog()
+	for i := 0; i < maxMessages+10; i++ {
+		l.Add(time.Now(), fmt.Sprintf("message %d", i), false)
+	}
+	entries := l.Entries()
+	if len(entries) != maxMessages {
+		t.Fatalf("Expected %d entries, got %d", maxMessages, len(entries))
+	}
+	if entries[0].Text != "message 10" {
+		t.Fatalf("Expected the oldest messages to be dropped, got %q", entries[0].Text)
+	}
+}
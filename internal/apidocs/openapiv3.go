@@ -0,0 +1,321 @@
+package apidocs
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	openapiclient "k8s.io/client-go/openapi"
+	"k8s.io/kube-openapi/pkg/spec3"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+const (
+	componentsSchemasPrefix = "#/components/schemas/"
+	// guards against malformed documents with reference cycles
+	maxReferenceDepth = 32
+)
+
+// FieldSchema is a field schema as it is declared in the parent,
+// and the schema of its type with all references resolved.
+type FieldSchema struct {
+	Declared *spec.Schema
+	Resolved *spec.Schema
+	// RefName is a name of the referenced definition (e.g. io.k8s.api.core.v1.PodSpec), if any
+	RefName string
+	// GVK is the kind of the resource, the field belongs to
+	GVK schema.GroupVersionKind
+	// Required is set, when the field is listed as required by its parent
+	Required bool
+
+	// doc is used for resolving references of child fields
+	doc *spec3.OpenAPI
+}
+
+// SchemaResolver resolves schemas of fields using OpenAPI v3 documents, documents are cached by group-version.
+// Failed fetches are cached as well, so a broken group-version is not fetched again for every field.
+// It's safe for concurrent use, filters resolve schemas off the UI goroutine.
+type SchemaResolver struct {
+	restMapper    meta.RESTMapper
+	openAPIClient openapiclient.Client
+
+	mu       sync.Mutex
+	paths    map[string]openapiclient.GroupVersion
+	pathsErr error
+	docs     map[schema.GroupVersion]*schemaDocument
+	docErrs  map[schema.GroupVersion]error
+}
+
+// schemaDocument is an OpenAPI v3 document of a group-version with an index of schemas of its kinds
+type schemaDocument struct {
+	doc   *spec3.OpenAPI
+	kinds map[schema.GroupVersionKind]string
+}
+
+func newSchemaDocument(doc *spec3.OpenAPI) *schemaDocument {
+	d := &schemaDocument{doc: doc, kinds: make(map[schema.GroupVersionKind]string)}
+	if doc.Components == nil {
+		return d
+	}
+	for name, s := range doc.Components.Schemas {
+		for _, gvk := range getSchemaGVKs(s) {
+			d.kinds[gvk] = name
+		}
+	}
+	return d
+}
+
+func NewSchemaResolver(restMapper meta.RESTMapper, openAPIClient openapiclient.Client) *SchemaResolver {
+	return &SchemaResolver{
+		restMapper:    restMapper,
+		openAPIClient: openAPIClient,
+		docs:          make(map[schema.GroupVersion]*schemaDocument),
+		docErrs:       make(map[schema.GroupVersion]error),
+	}
+}
+
+// ResolveField resolves a schema for a path like 'deployments.spec.replicas', the first segment is the resource name.
+func (r *SchemaResolver) ResolveField(gvr schema.GroupVersionResource, path string) (*FieldSchema, error) {
+	doc, err := r.getDocument(gvr.GroupVersion())
+	if err != nil {
+		return nil, err
+	}
+	gvk, err := r.restMapper.KindFor(gvr)
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Split(path, ".")
+	if len(fields) > 0 {
+		// Skip resource name
+		fields = fields[1:]
+	}
+	return resolveFieldSchema(doc, gvk, fields)
+}
+
+// ResolveDefinition resolves a definition, or a field of it, e.g. 'containers' of io.k8s.api.core.v1.PodSpec,
+// the document is chosen by a resource, that uses the definition
+func (r *SchemaResolver) ResolveDefinition(gvr schema.GroupVersionResource, refName, path string) (*FieldSchema, error) {
+	doc, err := r.getDocument(gvr.GroupVersion())
+	if err != nil {
+		return nil, err
+	}
+	gvk, err := r.restMapper.KindFor(gvr)
+	if err != nil {
+		return nil, err
+	}
+	fs, err := resolveDefinitionSchema(doc.doc, gvk, refName)
+	if err != nil || path == "" {
+		return fs, err
+	}
+	return descendFieldSchema(fs, strings.Split(path, "."))
+}
+
+// DeprecatedResources returns resources of a group-version, that operations are marked as deprecated
+// in the OpenAPI v3 document.
+func (r *SchemaResolver) DeprecatedResources(gv schema.GroupVersion) (map[string]bool, error) {
+	doc, err := r.getDocument(gv)
+	if err != nil {
+		return nil, err
+	}
+	return getDeprecatedResources(doc.doc, gv), nil
+}
+
+func (r *SchemaResolver) getDocument(gv schema.GroupVersion) (*schemaDocument, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if doc, ok := r.docs[gv]; ok {
+		return doc, nil
+	}
+	if err, ok := r.docErrs[gv]; ok {
+		return nil, err
+	}
+	doc, err := r.fetchDocument(gv)
+	if err != nil {
+		r.docErrs[gv] = err
+		return nil, err
+	}
+	r.docs[gv] = doc
+	return doc, nil
+}
+
+func (r *SchemaResolver) fetchDocument(gv schema.GroupVersion) (*schemaDocument, error) {
+	if r.paths == nil && r.pathsErr == nil {
+		r.paths, r.pathsErr = r.openAPIClient.Paths()
+		if r.pathsErr != nil {
+			r.pathsErr = fmt.Errorf("failed to fetch list of groupVersions: %w", r.pathsErr)
+		}
+	}
+	if r.pathsErr != nil {
+		return nil, r.pathsErr
+	}
+	paths := r.paths
+	resourcePath := fmt.Sprintf("apis/%s/%s", gv.Group, gv.Version)
+	if gv.Group == "" {
+		resourcePath = fmt.Sprintf("api/%s", gv.Version)
+	}
+	gvPaths, ok := paths[resourcePath]
+	if !ok {
+		return nil, fmt.Errorf("couldn't find openapi v3 document for %q", gv)
+	}
+	docBytes, err := gvPaths.Schema(runtime.ContentTypeJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch openapi schema for %s: %w", resourcePath, err)
+	}
+	doc := &spec3.OpenAPI{}
+	if err := json.Unmarshal(docBytes, doc); err != nil {
+		return nil, fmt.Errorf("failed to parse openapi schema for %s: %w", resourcePath, err)
+	}
+	return newSchemaDocument(doc), nil
+}
+
+func resolveFieldSchema(d *schemaDocument, gvk schema.GroupVersionKind, fields []string) (*FieldSchema, error) {
+	refName, ok := d.kinds[gvk]
+	if !ok {
+		return nil, fmt.Errorf("couldn't find schema for %q", gvk)
+	}
+	root := d.doc.Components.Schemas[refName]
+	return descendFieldSchema(&FieldSchema{Declared: root, Resolved: root, RefName: refName, GVK: gvk, doc: d.doc}, fields)
+}
+
+// descendFieldSchema resolves a schema of a child field, that is found by names of fields, starting from a schema
+func descendFieldSchema(result *FieldSchema, fields []string) (*FieldSchema, error) {
+	doc, gvk := result.doc, result.GVK
+	for _, field := range fields {
+		parent := getElementSchema(doc, result.Resolved)
+		prop, ok := parent.Properties[field]
+		if !ok {
+			return nil, fmt.Errorf("field %q does not exist in %q", field, gvk)
+		}
+		resolved, name := derefSchema(doc, &prop)
+		result = &FieldSchema{
+			Declared: &prop,
+			Resolved: resolved,
+			RefName:  name,
+			GVK:      gvk,
+			Required: slices.Contains(parent.Required, field),
+			doc:      doc,
+		}
+	}
+	return result, nil
+}
+
+// resolveDefinitionSchema resolves a definition by its name, e.g. io.k8s.api.core.v1.PodSpec
+func resolveDefinitionSchema(doc *spec3.OpenAPI, gvk schema.GroupVersionKind, refName string) (*FieldSchema, error) {
+	if doc.Components == nil {
+		return nil, fmt.Errorf("couldn't find definition %q", refName)
+	}
+	s, ok := doc.Components.Schemas[refName]
+	if !ok {
+		return nil, fmt.Errorf("couldn't find definition %q", refName)
+	}
+	resolved, _ := derefSchema(doc, s)
+	return &FieldSchema{Declared: s, Resolved: resolved, RefName: refName, GVK: gvk, doc: doc}, nil
+}
+
+func getSchemaGVKs(s *spec.Schema) []schema.GroupVersionKind {
+	values, ok := s.Extensions["x-kubernetes-group-version-kind"].([]interface{})
+	if !ok {
+		return nil
+	}
+	gvks := make([]schema.GroupVersionKind, 0, len(values))
+	for _, v := range values {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		gvk := schema.GroupVersionKind{}
+		gvk.Group, _ = m["group"].(string)
+		gvk.Version, _ = m["version"].(string)
+		gvk.Kind, _ = m["kind"].(string)
+		gvks = append(gvks, gvk)
+	}
+	return gvks
+}
+
+// derefSchema follows '$ref' and single-element 'allOf' wrappers, which are used for references in v3 documents.
+func derefSchema(doc *spec3.OpenAPI, s *spec.Schema) (*spec.Schema, string) {
+	var refName string
+	for i := 0; i < maxReferenceDepth && s != nil; i++ {
+		if ref := s.Ref.String(); ref != "" {
+			name := strings.TrimPrefix(ref, componentsSchemasPrefix)
+			target, ok := doc.Components.Schemas[name]
+			if !ok {
+				return s, refName
+			}
+			refName = name
+			s = target
+			continue
+		}
+		if len(s.AllOf) == 1 {
+			s = &s.AllOf[0]
+			continue
+		}
+		break
+	}
+	return s, refName
+}
+
+// getElementSchema descends through arrays and maps down to a schema that may hold properties.
+func getElementSchema(doc *spec3.OpenAPI, s *spec.Schema) *spec.Schema {
+	for i := 0; i < maxReferenceDepth && len(s.Properties) == 0; i++ {
+		switch {
+		case s.Items != nil && s.Items.Schema != nil:
+			s, _ = derefSchema(doc, s.Items.Schema)
+		case s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil:
+			s, _ = derefSchema(doc, s.AdditionalProperties.Schema)
+		default:
+			return s
+		}
+	}
+	return s
+}
+
+// getDeprecatedResources returns resources with deprecated operations on their collection or item paths
+func getDeprecatedResources(doc *spec3.OpenAPI, gv schema.GroupVersion) map[string]bool {
+	deprecated := make(map[string]bool)
+	if doc.Paths == nil {
+		return deprecated
+	}
+	for p, item := range doc.Paths.Paths {
+		if item == nil {
+			continue
+		}
+		resource := getPathResource(p, gv)
+		if resource == "" {
+			continue
+		}
+		for _, op := range []*spec3.Operation{item.Get, item.Put, item.Post, item.Delete, item.Patch} {
+			if op != nil && op.Deprecated {
+				deprecated[resource] = true
+				break
+			}
+		}
+	}
+	return deprecated
+}
+
+// getPathResource returns a resource of the group-version by its collection or item path:
+// '/apis/<group>/<version>/<resource>' ('/api/v1/<resource>' for the core group), '.../<resource>/{name}',
+// and the same under 'namespaces/{namespace}/'. It's empty for other paths: subresources, watches, other groups.
+func getPathResource(p string, gv schema.GroupVersion) string {
+	prefix := fmt.Sprintf("/apis/%s/%s/", gv.Group, gv.Version)
+	if gv.Group == "" {
+		prefix = fmt.Sprintf("/api/%s/", gv.Version)
+	}
+	rest, ok := strings.CutPrefix(p, prefix)
+	if !ok {
+		return ""
+	}
+	rest = strings.TrimPrefix(rest, "namespaces/{namespace}/")
+	rest = strings.TrimSuffix(rest, "/{name}")
+	if rest == "" || strings.Contains(rest, "/") {
+		return ""
+	}
+	return rest
+}
@@ -0,0 +1,187 @@
+package apidocs
+
+import (
+	"encoding/json"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/spec3"
+)
+
+const testOpenAPIDocument = `{
+  "openapi": "3.0.0",
+  "info": {"title": "Kubernetes", "version": "v1"},
+  "paths": {},
+  "components": {
+    "schemas": {
+      "io.k8s.api.apps.v1.Deployment": {
+        "type": "object",
+        "x-kubernetes-group-version-kind": [{"group": "apps", "kind": "Deployment", "version": "v1"}],
+        "properties": {
+          "spec": {"allOf": [{"$ref": "#/components/schemas/io.k8s.api.apps.v1.DeploymentSpec"}], "default": {}}
+        }
+      },
+      "io.k8s.api.apps.v1.DeploymentSpec": {
+        "type": "object",
+        "required": ["containers"],
+        "x-kubernetes-validations": [
+          {"rule": "self.replicas <= 10", "message": "At most 10 replicas", "reason": "FieldValueInvalid"}
+        ],
+        "properties": {
+          "replicas": {"type": "integer", "format": "int32", "minimum": 0},
+          "containers": {
+            "type": "array",
+            "items": {"allOf": [{"$ref": "#/components/schemas/io.k8s.api.core.v1.Container"}]}
+          }
+        }
+      },
+      "io.k8s.api.core.v1.Container": {
+        "type": "object",
+        "properties": {
+          "imagePullPolicy": {"type": "string", "enum": ["Always", "IfNotPresent", "Never"]},
+          "name": {"type": "string", "pattern": "^[a-z]+$", "maxLength": 63}
+        }
+      }
+    }
+  }
+}`
+
+var testDeploymentGVK = schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+
+func loadTestOpenAPIDocument(t *testing.T) *spec3.OpenAPI {
+	t.Helper()
+	doc := &spec3.OpenAPI{}
+	if err := json.Unmarshal([]byte(testOpenAPIDocument), doc); err != nil {
+		t.Fatalf("Failed to parse test document: %v", err)
+	}
+	return doc
+}
+
+func TestResolveFieldSchema_Root(t *testing.T) {
+	doc := loadTestOpenAPIDocument(t)
+
+	fs, err := resolveFieldSchema(newSchemaDocument(doc), testDeploymentGVK, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fs.RefName != "io.k8s.api.apps.v1.Deployment" {
+		t.Fatalf("Expected root definition, got %s", fs.RefName)
+	}
+}
+
+func TestResolveFieldSchema_Reference(t *testing.T) {
+	doc := loadTestOpenAPIDocument(t)
+
+	fs, err := resolveFieldSchema(newSchemaDocument(doc), testDeploymentGVK, []string{"spec"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fs.RefName != "io.k8s.api.apps.v1.DeploymentSpec" {
+		t.Fatalf("Expected DeploymentSpec reference, got %s", fs.RefName)
+	}
+	if _, ok := fs.Resolved.Properties["replicas"]; !ok {
+		t.Fatal("Expected resolved schema to hold 'replicas' property")
+	}
+}
+
+func TestResolveFieldSchema_ThroughArray(t *testing.T) {
+	doc := loadTestOpenAPIDocument(t)
+
+	fs, err := resolveFieldSchema(newSchemaDocument(doc), testDeploymentGVK, []string{"spec", "containers", "imagePullPolicy"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(fs.Resolved.Enum) != 3 {
+		t.Fatalf("Expected 3 enum values, got %v", fs.Resolved.Enum)
+	}
+}
+
+func TestResolveFieldSchema_UnknownField(t *testing.T) {
+	doc := loadTestOpenAPIDocument(t)
+
+	if _, err := resolveFieldSchema(newSchemaDocument(doc), testDeploymentGVK, []string{"spec", "unknown"}); err == nil {
+		t.Fatal("Expected error for unknown field")
+	}
+	if _, err := resolveFieldSchema(newSchemaDocument(doc), schema.GroupVersionKind{Kind: "Pod", Version: "v1"}, nil); err == nil {
+		t.Fatal("Expected error for unknown kind")
+	}
+}
+
+func TestGetSchemaConstraints(t *testing.T) {
+	doc := loadTestOpenAPIDocument(t)
+
+	fs, err := resolveFieldSchema(newSchemaDocument(doc), testDeploymentGVK, []string{"spec", "containers", "name"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	constraints := getSchemaConstraints(fs)
+	expected := []schemaConstraint{
+		{name: "pattern", value: "^[a-z]+$"},
+		{name: "maxLength", value: "63"},
+	}
+	if len(constraints) != len(expected) {
+		t.Fatalf("Expected %d constraints, got %+v", len(expected), constraints)
+	}
+	for i := range expected {
+		if constraints[i] != expected[i] {
+			t.Fatalf("Expected %+v, got %+v", expected[i], constraints[i])
+		}
+	}
+
+	fs, err = resolveFieldSchema(newSchemaDocument(doc), testDeploymentGVK, []string{"spec", "replicas"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	constraints = getSchemaConstraints(fs)
+	if len(constraints) != 2 || constraints[0].value != "0" || constraints[1].value != "int32" {
+		t.Fatalf("Unexpected constraints for replicas: %+v", constraints)
+	}
+}
+
+func TestGetPathResource(t *testing.T) {
+	apps := schema.GroupVersion{Group: "apps", Version: "v1"}
+	core := schema.GroupVersion{Version: "v1"}
+	tests := []struct {
+		path string
+		gv   schema.GroupVersion
+		want string
+	}{
+		{"/apis/apps/v1/deployments", apps, "deployments"},
+		{"/apis/apps/v1/namespaces/{namespace}/deployments", apps, "deployments"},
+		{"/apis/apps/v1/namespaces/{namespace}/deployments/{name}", apps, "deployments"},
+		{"/apis/apps/v1/namespaces/{namespace}/deployments/{name}/scale", apps, ""},
+		{"/apis/apps/v1/watch/namespaces/{namespace}/deployments", apps, ""},
+		{"/apis/apps/v1/", apps, ""},
+		{"/apis/extensions/v1beta1/deployments", apps, ""},
+		{"/apis/apps/v1beta1/deployments", apps, ""},
+		{"/api/v1/pods", core, "pods"},
+		{"/api/v1/namespaces/{namespace}/pods/{name}", core, "pods"},
+		{"/api/v1/namespaces/{name}", core, "namespaces"},
+		{"/apis/apps/v1/deployments", core, ""},
+	}
+	for _, tt := range tests {
+		if got := getPathResource(tt.path, tt.gv); got != tt.want {
+			t.Errorf("getPathResource(%q, %q) = %q, want %q", tt.path, tt.gv, got, tt.want)
+		}
+	}
+}
+
+func TestGetDeprecatedResources(t *testing.T) {
+	doc := &spec3.OpenAPI{}
+	err := json.Unmarshal([]byte(`{
+  "openapi": "3.0.0",
+  "paths": {
+    "/apis/batch/v1beta1/namespaces/{namespace}/cronjobs": {"get": {"deprecated": true}},
+    "/apis/batch/v1beta1/namespaces/{namespace}/cronjobs/{name}/status": {"get": {"deprecated": true}},
+    "/apis/batch/v1beta1/jobs": {"get": {}},
+    "/apis/other/v1beta1/jobs": {"get": {"deprecated": true}}
+  }
+}`), doc)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	deprecated := getDeprecatedResources(doc, schema.GroupVersion{Group: "batch", Version: "v1beta1"})
+	if len(deprecated) != 1 || !deprecated["cronjobs"] {
+		t.Fatalf("Unexpected deprecated resources: %v", deprecated)
+	}
+}
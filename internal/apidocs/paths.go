@@ -1,6 +1,7 @@
 package apidocs
 
 import (
+	"fmt"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -12,25 +13,60 @@ import (
 func getPaths(restMapper meta.RESTMapper,
 	openAPISchema openapi.Resources,
 	gvr schema.GroupVersionResource,
-) ([]string, error) {
+	references *ReferenceIndex,
+) ([]string, map[string]*FieldMeta, error) {
 	visitor := &schemaVisitor{
 		pathSchema:        make(map[string]proto.Schema),
 		prevPath:          strings.ToLower(gvr.Resource),
 		err:               nil,
 		visitedReferences: make(map[string]struct{}),
+		fieldMetas:        make(map[string]*FieldMeta),
+		gvr:               gvr,
+		references:        references,
 	}
 	gvk, err := restMapper.KindFor(gvr)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	protoSchema := openAPISchema.LookupResource(gvk)
 	if protoSchema == nil {
-		return nil, err
+		return nil, nil, fmt.Errorf("no OpenAPI schema for %s", gvk)
 	}
+	if isDeprecatedDescription(protoSchema.GetDescription()) {
+		visitor.getFieldMeta(visitor.prevPath).deprecated = true
+	}
+	// the resource is a usage of its own definition
+	references.add(protoSchema.GetPath().String(), TypeUsage{GVR: gvr, Path: visitor.prevPath}, protoSchema)
+	visitor.getFieldMeta(visitor.prevPath).definition = protoSchema.GetPath().String()
 	protoSchema.Accept(visitor)
 	if visitor.err != nil {
-		return nil, err
+		return nil, nil, visitor.err
 	}
 	visitorPathsResult := visitor.getVisitedPaths()
-	return visitorPathsResult, nil
+	return visitorPathsResult, visitor.fieldMetas, nil
+}
+
+// getRecursionPaths visits a recursive definition once more below the path, where it recurs,
+// the recursion points inside it are marked again
+func getRecursionPaths(references *ReferenceIndex,
+	gvr schema.GroupVersionResource,
+	definition string,
+	path string,
+) ([]string, map[string]*FieldMeta, error) {
+	protoSchema := references.Schema(definition)
+	if protoSchema == nil {
+		return nil, nil, fmt.Errorf("unknown definition: %q", definition)
+	}
+	visitor := &schemaVisitor{
+		pathSchema:        make(map[string]proto.Schema),
+		prevPath:          path,
+		visitedReferences: map[string]struct{}{definition: {}},
+		fieldMetas:        make(map[string]*FieldMeta),
+		gvr:               gvr,
+	}
+	protoSchema.Accept(visitor)
+	if visitor.err != nil {
+		return nil, nil, visitor.err
+	}
+	return visitor.getVisitedPaths(), visitor.fieldMetas, nil
 }
@@ -0,0 +1,256 @@
+package apidocs
+
+import (
+	"testing"
+
+	openapi_v2 "github.com/google/gnostic-models/openapiv2"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kubectl/pkg/util/openapi"
+)
+
+const testSwaggerDocument = `{
+  "swagger": "2.0",
+  "info": {"title": "test", "version": "v1"},
+  "paths": {},
+  "definitions": {
+    "io.k8s.api.apps.v1.Deployment": {
+      "type": "object",
+      "properties": {
+        "spec": {"$ref": "#/definitions/io.k8s.api.apps.v1.DeploymentSpec"}
+      },
+      "x-kubernetes-group-version-kind": [{"group": "apps", "version": "v1", "kind": "Deployment"}]
+    },
+    "io.k8s.api.apps.v1.DeploymentSpec": {
+      "type": "object",
+      "properties": {
+        "containers": {"type": "array", "items": {"$ref": "#/definitions/io.k8s.api.core.v1.Container"}},
+        "initContainers": {"type": "array", "items": {"$ref": "#/definitions/io.k8s.api.core.v1.Container"}},
+        "replicas": {"type": "integer"},
+        "selector": {"$ref": "#/definitions/io.k8s.test.v1.Node"}
+      }
+    },
+    "io.k8s.test.v1.Node": {
+      "type": "object",
+      "properties": {
+        "children": {"type": "array", "items": {"$ref": "#/definitions/io.k8s.test.v1.Node"}},
+        "name": {"type": "string"}
+      }
+    },
+    "io.k8s.api.core.v1.Container": {
+      "type": "object",
+      "properties": {
+        "name": {"type": "string"}
+      }
+    }
+  }
+}`
+
+var testDeploymentGVR = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+func loadTestOpenAPIResources(t *testing.T) openapi.Resources {
+	t.Helper()
+	return parseTestOpenAPIResources(t, testSwaggerDocument)
+}
+
+func parseTestOpenAPIResources(t *testing.T, document string) openapi.Resources {
+	t.Helper()
+	doc, err := openapi_v2.ParseDocument([]byte(document))
+	if err != nil {
+		t.Fatalf("Failed to parse test document: %v", err)
+	}
+	resources, err := openapi.NewOpenAPIData(doc)
+	if err != nil {
+		t.Fatalf("Failed to load test document: %v", err)
+	}
+	return resources
+}
+
+func TestGetPaths(t *testing.T) {
+	references := NewReferenceIndex()
+	paths, fieldMetas, err := getPaths(newTestRESTMapper(), loadTestOpenAPIResources(t), testDeploymentGVR, references)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []string{
+		"deployments.spec",
+		"deployments.spec.containers",
+		"deployments.spec.containers.name",
+		"deployments.spec.initContainers",
+		"deployments.spec.initContainers.name",
+		"deployments.spec.replicas",
+		"deployments.spec.selector",
+		"deployments.spec.selector.children",
+		"deployments.spec.selector.name",
+	}
+	if len(paths) != len(expected) {
+		t.Fatalf("Expected paths %v, got %v", expected, paths)
+	}
+	for i := range expected {
+		if paths[i] != expected[i] {
+			t.Fatalf("Expected paths %v, got %v", expected, paths)
+		}
+	}
+
+	// fields and the resource know their definitions
+	for path, definition := range map[string]string{
+		"deployments":                 "io.k8s.api.apps.v1.Deployment",
+		"deployments.spec":            "io.k8s.api.apps.v1.DeploymentSpec",
+		"deployments.spec.containers": "io.k8s.api.core.v1.Container",
+		"deployments.spec.replicas":   "",
+	} {
+		if actual := fieldMetas[path].getDefinition(); actual != definition {
+			t.Fatalf("%s: expected definition %q, got %q", path, definition, actual)
+		}
+	}
+}
+
+func TestGetPaths_NestedCollections(t *testing.T) {
+	resources := parseTestOpenAPIResources(t, `{
+  "swagger": "2.0",
+  "info": {"title": "test", "version": "v1"},
+  "paths": {},
+  "definitions": {
+    "io.k8s.api.apps.v1.Deployment": {
+      "type": "object",
+      "properties": {
+        "matrix": {
+          "type": "array",
+          "x-kubernetes-list-type": "atomic",
+          "items": {"type": "array", "items": {"type": "integer"}}
+        },
+        "labels": {
+          "type": "object",
+          "x-kubernetes-map-type": "granular",
+          "additionalProperties": {"type": "array", "x-kubernetes-list-type": "set", "items": {"type": "string"}}
+        },
+        "ports": {
+          "type": "array",
+          "x-kubernetes-list-type": "map",
+          "x-kubernetes-list-map-keys": ["port", "protocol"],
+          "x-kubernetes-patch-merge-key": "port",
+          "x-kubernetes-patch-strategy": "merge",
+          "items": {"type": "array", "items": {"type": "string"}}
+        }
+      },
+      "x-kubernetes-group-version-kind": [{"group": "apps", "version": "v1", "kind": "Deployment"}]
+    }
+  }
+}`)
+	_, fieldMetas, err := getPaths(newTestRESTMapper(), resources, testDeploymentGVR, NewReferenceIndex())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// inner collections don't override the outer one
+	for path, expected := range map[string]string{
+		"deployments.matrix": "{list=atomic}",
+		"deployments.labels": "{map=granular}",
+		"deployments.ports":  "{list=map(port,protocol) patch=merge(port)}",
+	} {
+		fieldMeta := fieldMetas[path]
+		if actual := fieldMeta.getCollectionAnnotation(); actual != expected {
+			t.Fatalf("%s: expected %q, got %q", path, expected, actual)
+		}
+	}
+	if m := fieldMetas["deployments.labels"]; !m.isMap || m.isArray {
+		t.Fatalf("Expected labels to be a map only, got %+v", m)
+	}
+}
+
+func TestReferenceIndex(t *testing.T) {
+	references := NewReferenceIndex()
+	if _, _, err := getPaths(newTestRESTMapper(), loadTestOpenAPIResources(t), testDeploymentGVR, references); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	names := references.Names()
+	expectedNames := []string{
+		"io.k8s.api.apps.v1.Deployment",
+		"io.k8s.api.apps.v1.DeploymentSpec",
+		"io.k8s.api.core.v1.Container",
+		"io.k8s.test.v1.Node",
+	}
+	if len(names) != len(expectedNames) {
+		t.Fatalf("Expected definitions %v, got %v", expectedNames, names)
+	}
+	for i := range expectedNames {
+		if names[i] != expectedNames[i] {
+			t.Fatalf("Expected definitions %v, got %v", expectedNames, names)
+		}
+	}
+
+	usages := references.Usages("io.k8s.api.core.v1.Container")
+	if len(usages) != 2 || usages[0].Path != "deployments.spec.containers" || usages[1].Path != "deployments.spec.initContainers" {
+		t.Fatalf("Unexpected usages of containers: %+v", usages)
+	}
+	if usages[0].GVR != testDeploymentGVR {
+		t.Fatalf("Unexpected resource of a usage: %v", usages[0].GVR)
+	}
+	if usages := references.Usages("io.k8s.api.apps.v1.Deployment"); len(usages) != 1 || usages[0].Path != "deployments" {
+		t.Fatalf("Unexpected usages of the resource: %+v", usages)
+	}
+
+	if name, err := references.Find("Container"); err != nil || name != "io.k8s.api.core.v1.Container" {
+		t.Fatalf("Unexpected definition by a short name: %q, %v", name, err)
+	}
+	if _, err := references.Find("Secret"); err == nil {
+		t.Fatalf("Expected an error for an unknown definition")
+	}
+	candidates := references.GetCandidates()
+	if len(candidates) != 8 || candidates[0] != "Container" || candidates[4] != "io.k8s.api.apps.v1.Deployment" {
+		t.Fatalf("Unexpected candidates: %v", candidates)
+	}
+
+	fields := getDefinitionFields(references.Schema("io.k8s.api.apps.v1.DeploymentSpec"))
+	if len(fields) != 4 || fields[0] != "containers" || fields[3] != "selector" {
+		t.Fatalf("Unexpected fields: %v", fields)
+	}
+}
+
+func TestGetRecursionPaths(t *testing.T) {
+	references := NewReferenceIndex()
+	_, fieldMetas, err := getPaths(newTestRESTMapper(), loadTestOpenAPIResources(t), testDeploymentGVR, references)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// the recursion stops at the field, that refers to the type again
+	recursion := fieldMetas["deployments.spec.selector.children"].getRecursion()
+	if recursion != "io.k8s.test.v1.Node" {
+		t.Fatalf("Expected a recursion point, got %q", recursion)
+	}
+	if usages := references.Usages(recursion); len(usages) != 2 {
+		t.Fatalf("Expected the recursive field among usages, got %+v", usages)
+	}
+
+	// one more level below the recursion point, that stops at the recursion again
+	paths, fieldMetas, err := getRecursionPaths(references, testDeploymentGVR, recursion, "deployments.spec.selector.children")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []string{
+		"deployments.spec.selector.children.children",
+		"deployments.spec.selector.children.name",
+	}
+	if len(paths) != len(expected) || paths[0] != expected[0] || paths[1] != expected[1] {
+		t.Fatalf("Expected paths %v, got %v", expected, paths)
+	}
+	if fieldMetas["deployments.spec.selector.children.children"].getRecursion() != recursion {
+		t.Fatalf("Expected a recursion point below the recursion")
+	}
+
+	if _, _, err := getRecursionPaths(references, testDeploymentGVR, "io.k8s.test.v1.Unknown", "deployments"); err == nil {
+		t.Fatalf("Expected an error for an unknown definition")
+	}
+}
+
+func TestGetPaths_Errors(t *testing.T) {
+	// the kind is known to the mapper, but the document has no schema for it
+	podsGVR := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	if _, _, err := getPaths(newTestRESTMapper(), loadTestOpenAPIResources(t), podsGVR, nil); err == nil {
+		t.Fatalf("Expected an error for a resource without a schema")
+	}
+	// the resource is unknown to the mapper
+	widgetsGVR := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+	if _, _, err := getPaths(newTestRESTMapper(), loadTestOpenAPIResources(t), widgetsGVR, nil); err == nil {
+		t.Fatalf("Expected an error for an unknown resource")
+	}
+}
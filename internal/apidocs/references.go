@@ -0,0 +1,124 @@
+package apidocs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/util/proto"
+)
+
+// TypeUsage is a place, where a definition is used: a resource, or a field of a resource
+type TypeUsage struct {
+	GVR  schema.GroupVersionResource
+	Path string
+}
+
+// ReferenceIndex maps OpenAPI definitions (e.g. io.k8s.api.core.v1.Container) to resources and fields using them,
+// it's filled by the schemaVisitor, while it walks references of all resources
+type ReferenceIndex struct {
+	usages  map[string][]TypeUsage
+	schemas map[string]proto.Schema
+}
+
+func NewReferenceIndex() *ReferenceIndex {
+	return &ReferenceIndex{
+		usages:  make(map[string][]TypeUsage),
+		schemas: make(map[string]proto.Schema),
+	}
+}
+
+func (i *ReferenceIndex) add(name string, usage TypeUsage, s proto.Schema) {
+	if i == nil || name == "" {
+		return
+	}
+	i.usages[name] = append(i.usages[name], usage)
+	if _, ok := i.schemas[name]; !ok {
+		i.schemas[name] = s
+	}
+}
+
+// Names returns names of all definitions, sorted
+func (i *ReferenceIndex) Names() []string {
+	names := make([]string, 0, len(i.usages))
+	for name := range i.usages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Usages returns resources and fields using a definition, in the order they were visited
+func (i *ReferenceIndex) Usages(name string) []TypeUsage {
+	return i.usages[name]
+}
+
+// Schema returns a schema of a definition, nil if it's unknown
+func (i *ReferenceIndex) Schema(name string) proto.Schema {
+	return i.schemas[name]
+}
+
+// Find returns a definition by its full name, or by a short name, e.g. LabelSelector, when it's unambiguous
+func (i *ReferenceIndex) Find(name string) (string, error) {
+	if _, ok := i.usages[name]; ok {
+		return name, nil
+	}
+	var found []string
+	for _, n := range i.Names() {
+		if getShortRefName(n) == name {
+			found = append(found, n)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return "", fmt.Errorf("unknown definition: %q", name)
+	case 1:
+		return found[0], nil
+	default:
+		return "", fmt.Errorf("ambiguous definition %q: %s", name, strings.Join(found, ", "))
+	}
+}
+
+// GetCandidates returns full names of definitions, and short names, that are unambiguous, for the completion
+func (i *ReferenceIndex) GetCandidates() []string {
+	names := i.Names()
+	shortNames := make(map[string]int, len(names))
+	for _, n := range names {
+		shortNames[getShortRefName(n)]++
+	}
+	candidates := make([]string, 0, len(names)+len(shortNames))
+	for short, count := range shortNames {
+		if count == 1 {
+			candidates = append(candidates, short)
+		}
+	}
+	sort.Strings(candidates)
+	return append(candidates, names...)
+}
+
+// getSchemaReference returns a name of the definition of a schema, or of elements of an array or a map
+func getSchemaReference(s proto.Schema) string {
+	for i := 0; i < maxReferenceDepth && s != nil; i++ {
+		switch t := s.(type) {
+		case proto.Reference:
+			return t.Reference()
+		case *proto.Array:
+			s = t.SubType
+		case *proto.Map:
+			s = t.SubType
+		default:
+			return ""
+		}
+	}
+	return ""
+}
+
+// getDefinitionFields returns sorted names of direct fields of a definition, it's empty for primitives
+func getDefinitionFields(s proto.Schema) []string {
+	kind, ok := s.(*proto.Kind)
+	if !ok {
+		return nil
+	}
+	return kind.Keys()
+}
@@ -0,0 +1,195 @@
+package apidocs
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const (
+	themeDark         = "dark"
+	themeLight        = "light"
+	themeSolarized    = "solarized"
+	themeHighContrast = "high-contrast"
+	themeMonochrome   = "monochrome"
+)
+
+// Theme holds colors of views, tree nodes, and color tags used in texts
+type Theme struct {
+	background    tcell.Color
+	text          tcell.Color
+	graphics      tcell.Color
+	focusBorder   tcell.Color
+	noFocusBorder tcell.Color
+
+	// tree nodes
+	root     tcell.Color
+	group    tcell.Color
+	resource tcell.Color
+	field    tcell.Color
+
+	// keys in the help menu, input labels, bookmarks
+	accent tcell.Color
+	// merge annotations of fields
+	annotation tcell.Color
+	deprecated tcell.Color
+	allowed    tcell.Color
+	forbidden  tcell.Color
+	// errors in the status line
+	alert tcell.Color
+
+	// no colors at all, the selected node is shown in reverse video
+	monochrome bool
+}
+
+var (
+	solarizedBase03 = tcell.NewHexColor(0x002b36)
+	solarizedBase01 = tcell.NewHexColor(0x586e75)
+	solarizedBase0  = tcell.NewHexColor(0x839496)
+	solarizedYellow = tcell.NewHexColor(0xb58900)
+	solarizedOrange = tcell.NewHexColor(0xcb4b16)
+	solarizedRed    = tcell.NewHexColor(0xdc322f)
+	solarizedBlue   = tcell.NewHexColor(0x268bd2)
+	solarizedGreen  = tcell.NewHexColor(0x859900)
+)
+
+var themes = map[string]*Theme{
+	themeDark: {
+		background:    tcell.ColorBlack,
+		text:          tcell.ColorLightGray,
+		graphics:      tcell.ColorWhite,
+		focusBorder:   tcell.ColorSteelBlue,
+		noFocusBorder: tcell.ColorLightGray,
+		root:          tcell.ColorYellow,
+		group:         tcell.ColorGreen,
+		resource:      tcell.ColorSteelBlue,
+		field:         tcell.ColorLightGray,
+		accent:        tcell.ColorYellow,
+		annotation:    tcell.ColorGray,
+		deprecated:    tcell.ColorOrange,
+		allowed:       tcell.ColorGreen,
+		forbidden:     tcell.ColorRed,
+		alert:         tcell.ColorRed,
+	},
+	themeLight: {
+		background:    tcell.ColorWhite,
+		text:          tcell.ColorBlack,
+		graphics:      tcell.ColorDarkGray,
+		focusBorder:   tcell.ColorBlue,
+		noFocusBorder: tcell.ColorDarkGray,
+		root:          tcell.ColorPurple,
+		group:         tcell.ColorDarkGreen,
+		resource:      tcell.ColorBlue,
+		field:         tcell.ColorBlack,
+		accent:        tcell.ColorDarkMagenta,
+		annotation:    tcell.ColorDimGray,
+		deprecated:    tcell.ColorChocolate,
+		allowed:       tcell.ColorDarkGreen,
+		forbidden:     tcell.ColorFireBrick,
+		alert:         tcell.ColorFireBrick,
+	},
+	themeSolarized: {
+		background:    solarizedBase03,
+		text:          solarizedBase0,
+		graphics:      solarizedBase01,
+		focusBorder:   solarizedBlue,
+		noFocusBorder: solarizedBase01,
+		root:          solarizedYellow,
+		group:         solarizedGreen,
+		resource:      solarizedBlue,
+		field:         solarizedBase0,
+		accent:        solarizedYellow,
+		annotation:    solarizedBase01,
+		deprecated:    solarizedOrange,
+		allowed:       solarizedGreen,
+		forbidden:     solarizedRed,
+		alert:         solarizedRed,
+	},
+	themeHighContrast: {
+		background:    tcell.ColorBlack,
+		text:          tcell.ColorWhite,
+		graphics:      tcell.ColorWhite,
+		focusBorder:   tcell.ColorYellow,
+		noFocusBorder: tcell.ColorWhite,
+		root:          tcell.ColorYellow,
+		group:         tcell.ColorLime,
+		resource:      tcell.ColorAqua,
+		field:         tcell.ColorWhite,
+		accent:        tcell.ColorYellow,
+		annotation:    tcell.ColorSilver,
+		deprecated:    tcell.ColorOrange,
+		allowed:       tcell.ColorLime,
+		forbidden:     tcell.ColorRed,
+		alert:         tcell.ColorRed,
+	},
+	themeMonochrome: {
+		background:    tcell.ColorDefault,
+		text:          tcell.ColorDefault,
+		graphics:      tcell.ColorDefault,
+		focusBorder:   tcell.ColorDefault,
+		noFocusBorder: tcell.ColorDefault,
+		root:          tcell.ColorDefault,
+		group:         tcell.ColorDefault,
+		resource:      tcell.ColorDefault,
+		field:         tcell.ColorDefault,
+		accent:        tcell.ColorDefault,
+		annotation:    tcell.ColorDefault,
+		deprecated:    tcell.ColorDefault,
+		allowed:       tcell.ColorDefault,
+		forbidden:     tcell.ColorDefault,
+		alert:         tcell.ColorDefault,
+		monochrome:    true,
+	},
+}
+
+// theme is the active theme, it is set at the start from the config file, and switched by ':theme',
+// see applyTheme
+var theme = themes[themeDark]
+
+// getTheme returns a theme by name, when the name is not set, NO_COLOR (https://no-color.org) selects the monochrome theme
+func getTheme(name string) (*Theme, error) {
+	if name == "" {
+		if os.Getenv("NO_COLOR") != "" {
+			return themes[themeMonochrome], nil
+		}
+		return themes[themeDark], nil
+	}
+	t, ok := themes[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown theme: %q (available: %s)", name, strings.Join(getThemeNames(), ", "))
+	}
+	return t, nil
+}
+
+func getThemeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// apply sets the default styles of tview, they are used by views created afterward (popups, lists, etc...)
+func (t *Theme) apply() {
+	tview.Styles.PrimitiveBackgroundColor = t.background
+	tview.Styles.ContrastBackgroundColor = t.focusBorder
+	tview.Styles.MoreContrastBackgroundColor = t.group
+	tview.Styles.BorderColor = t.noFocusBorder
+	tview.Styles.TitleColor = t.text
+	tview.Styles.GraphicsColor = t.graphics
+	tview.Styles.PrimaryTextColor = t.text
+	tview.Styles.SecondaryTextColor = t.accent
+	tview.Styles.TertiaryTextColor = t.group
+	tview.Styles.InverseTextColor = t.resource
+	tview.Styles.ContrastSecondaryTextColor = t.root
+}
+
+// colorize wraps a text in a color tag, the text is expected to be escaped
+func (t *Theme) colorize(color tcell.Color, text string) string {
+	return fmt.Sprintf("[%s]%s[-]", color, text)
+}
@@ -0,0 +1,23 @@
+package apidocs
+
+import "testing"
+
+func TestGetTheme(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	if th, err := getTheme(""); err != nil || th != themes[themeDark] {
+		t.Fatalf("Expected the dark theme by default, got %v", err)
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if th, err := getTheme(""); err != nil || !th.monochrome {
+		t.Fatalf("Expected the monochrome theme with NO_COLOR, got %v", err)
+	}
+	// a theme set in the config file wins over NO_COLOR
+	if th, err := getTheme(themeLight); err != nil || th != themes[themeLight] {
+		t.Fatalf("Expected the light theme, got %v", err)
+	}
+
+	if _, err := getTheme("neon"); err == nil {
+		t.Fatal("Expected error for unknown theme")
+	}
+}
@@ -14,18 +14,48 @@ var (
 	nodeTypeGroup    TreeDataNodeType = "group"
 	nodeTypeResource TreeDataNodeType = "resource"
 	nodeTypeField    TreeDataNodeType = "field"
+
+	// OpenAPI definitions, their fields, and resources and fields using them
+	nodeTypeDefinition      TreeDataNodeType = "definition"
+	nodeTypeDefinitionField TreeDataNodeType = "definition-field"
+	nodeTypeUsage           TreeDataNodeType = "usage"
+
+	// a field, where a recursive type recurs, e.g. '↻ JSONSchemaProps', it's expanded on demand
+	nodeTypeRecursion TreeDataNodeType = "recursion"
+
+	// a resource, that fields can't be loaded for
+	nodeTypeError TreeDataNodeType = "error"
 )
 
 // TreeData is used for store custom properties in *tview.TreeNode references
 type TreeData struct {
 	nodeType TreeDataNodeType
 
+	// the text of a decorated node without decorations, the node text is rendered from it
+	// in the colors of the current theme, see renderNodeText
+	label      string
+	expandable bool // the field has children, or it's a recursion point, it's marked by '>'
+	bookmarked bool
+
 	// it means, that node is already opened in sub-view
 	// do not add it to a stack view again and again
 	inPreview bool
 
 	path string
 	gvr  *schema.GroupVersionResource
+	meta *FieldMeta
+
+	// a name of the definition, e.g. io.k8s.api.core.v1.Container (definitions and their fields only)
+	definition string
+
+	// the reason, why fields of the resource can't be loaded (error nodes only)
+	err error
+
+	// verbs of the resource split by the current identity permissions (resource nodes only)
+	access         *ResourceAccess
+	allowedVerbs   []string
+	forbiddenVerbs []string
+	unknownVerbs   []string // the review is incomplete, see ResourceAccess.splitVerbs
 }
 
 func extractTreeData(node *tview.TreeNode) (*TreeData, error) {
@@ -1,16 +1,17 @@
 package apidocs
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"sort"
-	"strings"
 	"sync"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/client-go/discovery"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
 	openapiclient "k8s.io/client-go/openapi"
 	"k8s.io/kubectl/pkg/util/openapi"
 
@@ -24,6 +25,13 @@ type UIData struct {
 	RestMapper      meta.RESTMapper
 	OpenAPISchema   openapi.Resources
 	OpenAPIClient   openapiclient.Client
+	AuthClient      authorizationv1client.SelfSubjectRulesReviewsGetter
+	Namespace       string
+	// Context and Server describe the cluster for the ':ctx' command
+	Context string
+	Server  string
+	// InitialPath is a path to open at the start, e.g. 'pods.spec.containers'
+	InitialPath string
 }
 
 type cmdInputPurpose string
@@ -35,17 +43,40 @@ var (
 
 type UIState struct {
 	app                     *tview.Application
+	screen                  tcell.Screen
+	pages                   *tview.Pages
 	apiResourcesRootNode    *tview.TreeNode
 	apiResourcesTreeView    *tview.TreeView
 	apiResourcesDetailsView *tview.TextView
 	apiResourcesViewsLayout *tview.Flex
 	mainLayout              *tview.Flex
 	cmdInput                *tview.InputField
+	breadcrumb              *tview.TextView
+	helpMenu                *tview.TextView
+	statusLine              *tview.TextView
+	breadcrumbNodes         []*tview.TreeNode // nodes of the breadcrumb segments, from the root
+	breadcrumbIndex         int               // selected segment, when the breadcrumb is focused
 	cmdInputIsOn            bool
 	cmdInputPurpose         cmdInputPurpose
 	treeLinks               *TreeLinks
 	explainCache            *sync.Map
-	isInFilter              bool // whether current resources view filtered by search CMD
+	explainFailures         map[schema.GroupVersion]bool // group-versions, that failures of explain were reported for
+	schemaResolver          *SchemaResolver
+	references              *ReferenceIndex
+	isInFilter              bool              // whether current resources view filtered by search CMD
+	navigationStack         []*tview.TreeNode // nodes opened in preview by ENTER, the root node is always at the bottom
+	bookmarks               *Bookmarks
+	history                 *History
+	keymap                  *Keymap
+	commands                *CommandRegistry
+	treePaneSize            int             // fixed size of the tree pane, when the split was resized, zero means a half
+	isResizing              bool            // the split is being dragged by the mouse
+	layoutMode              string          // auto, horizontal or vertical
+	screenWidth             int             // the auto layout depends on it
+	zoomedPane              tview.Primitive // a pane shown in the full size, if any
+	messages                *MessageLog     // status messages and errors, see :messages
+	detailsPage             *detailsPage    // the schema in the details pane, if it was resolved
+	detailsBack             []*detailsPage  // pages, that links were followed from
 }
 
 func RunApp(uiData *UIData) error {
@@ -55,8 +86,32 @@ func RunApp(uiData *UIData) error {
 		return fmt.Errorf("error getting API serverPreferredResources: %v", err)
 	}
 
-	// Create a new tview application
+	// Load the config file, invalid bindings fail the start, so they don't go unnoticed
+	config, err := loadConfigFromConfigDir()
+	if err != nil {
+		return err
+	}
+	keymap, err := NewKeymap(config.Keymap)
+	if err != nil {
+		return err
+	}
+	theme, err = getTheme(config.Theme)
+	if err != nil {
+		return err
+	}
+	theme.apply()
+	layoutMode, err := getLayoutMode(config.Layout)
+	if err != nil {
+		return err
+	}
+
+	// Create a new tview application, the screen is kept for clipboard access
+	screen, err := tcell.NewScreen()
+	if err != nil {
+		return err
+	}
 	app := tview.NewApplication()
+	app.SetScreen(screen)
 
 	// Create the root tree node
 	apiResourcesRootNode := tview.NewTreeNode("API Resources >").
@@ -65,27 +120,43 @@ func RunApp(uiData *UIData) error {
 	// Sort the API groups with custom logic to prioritize apps/v1 and v1 at the top
 	customSortGroups(serverPreferredResources)
 
+	// Review permissions of the current identity, the tree is usable without them
+	access, accessErr := loadResourceAccess(context.TODO(), uiData.AuthClient, uiData.Namespace)
+
+	// Look up deprecated resources once per group-version, the tree is usable without them
+	schemaResolver := NewSchemaResolver(uiData.RestMapper, uiData.OpenAPIClient)
+	deprecated, deprecatedErrs := loadDeprecatedResources(schemaResolver, serverPreferredResources)
+
+	// Bookmarks are optional, a broken file is reported in the status line and left untouched
+	bookmarks, bookmarksErr := loadBookmarksFromConfigDir()
+
 	// Populate root node with groups/resources/fields
-	err = populateRootNodeWithResources(apiResourcesRootNode, uiData, serverPreferredResources)
+	references := NewReferenceIndex()
+	err = populateRootNodeWithResources(
+		apiResourcesRootNode, uiData, access, deprecated, references, serverPreferredResources,
+	)
 	if err != nil {
 		return err
 	}
 
+	// Definitions are listed after the groups, they're browsed independently of resources
+	apiResourcesRootNode.AddChild(createDefinitionsNode(references))
+
 	// Create the help menu (top)
 	helpMenu := tview.NewTextView()
 	helpMenu.SetDynamicColors(true)
 	helpMenu.SetTextAlign(tview.AlignLeft)
-	helpMenu.SetText(getHelpMenuContent())
+	helpMenu.SetText(getHelpMenuContent(keymap))
 	helpMenu.SetBorder(true)
 
 	// Create a main tree view (lhs)
 	apiResourcesTreeView := tview.NewTreeView()
 	apiResourcesTreeView.SetRoot(apiResourcesRootNode)
 	apiResourcesTreeView.SetCurrentNode(apiResourcesRootNode)
-	apiResourcesTreeView.SetGraphicsColor(tcell.ColorWhite)
+	apiResourcesTreeView.SetGraphicsColor(theme.graphics)
 	apiResourcesTreeView.SetTitle("Resources")
 	apiResourcesTreeView.SetBorder(true)
-	apiResourcesTreeView.SetBorderColor(focusColor)
+	apiResourcesTreeView.SetBorderColor(theme.focusBorder)
 
 	// Create a main details view (rhs)
 	apiResourcesDetailsView := tview.NewTextView()
@@ -94,7 +165,21 @@ func RunApp(uiData *UIData) error {
 	apiResourcesDetailsView.SetTitle("Details")
 	apiResourcesDetailsView.SetScrollable(true)
 	apiResourcesDetailsView.SetWrap(true)
-	apiResourcesDetailsView.SetTextColor(tcell.ColorLightGray)
+	apiResourcesDetailsView.SetRegions(true) // type references are links, see detailsPage
+	apiResourcesDetailsView.SetTextColor(theme.text)
+
+	// Create a breadcrumb line, that shows the location of the current node (top of the tree)
+	breadcrumb := tview.NewTextView()
+	breadcrumb.SetDynamicColors(true)
+	breadcrumb.SetRegions(true)
+	breadcrumb.SetWrap(false)
+	breadcrumb.SetTextColor(theme.text)
+
+	// Create a status line for messages and errors of commands (bottom)
+	statusLine := tview.NewTextView()
+	statusLine.SetDynamicColors(true)
+	statusLine.SetWrap(false)
+	statusLine.SetTextColor(theme.text)
 
 	// Create a horizontal flex layout for resources-tree-view and resources-details-view
 	apiResourcesViewsLayout := tview.NewFlex()
@@ -104,44 +189,93 @@ func RunApp(uiData *UIData) error {
 	// Create a main layout for app
 	mainLayout := tview.NewFlex()
 	mainLayout.SetDirection(tview.FlexRow)
-	mainLayout.AddItem(helpMenu, 4, 1, false)
+	if !config.HideHelpBar {
+		mainLayout.AddItem(helpMenu, 4, 1, false)
+	}
+	mainLayout.AddItem(breadcrumb, 1, 1, false)
 	mainLayout.AddItem(apiResourcesViewsLayout, 0, 2, true)
+	mainLayout.AddItem(statusLine, 1, 1, false)
+
+	// Create pages, popups are shown over the main layout
+	pages := tview.NewPages()
+	pages.AddPage(pageMain, mainLayout, true, true)
 
 	// Create the input field (bottom, hidden by default)
 	cmdInput := tview.NewInputField()
 	cmdInput.SetLabel("Command: ")
 	cmdInput.SetFieldWidth(32)
 	cmdInput.SetBorder(true)
-	cmdInput.SetFieldTextColor(tcell.ColorLightGray)
-	cmdInput.SetBackgroundColor(tcell.ColorBlack)
-	cmdInput.SetLabelColor(tcell.ColorYellow)
-	cmdInput.SetFieldBackgroundColor(tcell.ColorBlack)
+	cmdInput.SetFieldTextColor(theme.text)
+	cmdInput.SetBackgroundColor(theme.background)
+	cmdInput.SetLabelColor(theme.accent)
+	cmdInput.SetFieldBackgroundColor(theme.background)
 
 	// parent/child relationships (used for searching)
 	treeLinks := NewTreeLinks()
 	treeLinks.FillLinks(apiResourcesRootNode)
 
 	// Set up listeners for app state.
-	err = setupListeners(uiData, &UIState{
+	uiState := &UIState{
 		app:                     app,
+		screen:                  screen,
+		pages:                   pages,
 		apiResourcesRootNode:    apiResourcesRootNode,
 		apiResourcesTreeView:    apiResourcesTreeView,
 		apiResourcesDetailsView: apiResourcesDetailsView,
 		apiResourcesViewsLayout: apiResourcesViewsLayout,
 		mainLayout:              mainLayout,
+		breadcrumb:              breadcrumb,
+		helpMenu:                helpMenu,
+		statusLine:              statusLine,
 		cmdInput:                cmdInput,
 		treeLinks:               treeLinks,
 		explainCache:            &sync.Map{},
-	})
+		explainFailures:         make(map[schema.GroupVersion]bool),
+		schemaResolver:          schemaResolver,
+		references:              references,
+		navigationStack:         []*tview.TreeNode{apiResourcesRootNode},
+		bookmarks:               bookmarks,
+		history:                 NewHistory(),
+		keymap:                  keymap,
+		commands:                NewCommandRegistry(),
+		layoutMode:              layoutMode,
+		messages:                NewMessageLog(),
+	}
+	err = setupListeners(uiData, uiState)
 	if err != nil {
 		return err
 	}
+	setupMouse(uiData, uiState)
+	setupLayout(uiState)
+
+	// Decorate bookmarked nodes
+	markBookmarkedNodes(uiState)
+	updateBreadcrumb(uiState, apiResourcesRootNode)
 
 	// Set colors
 	resetNodeColors(apiResourcesRootNode)
 
+	// The tree is usable without permissions, the failed review is reported in the status line
+	if accessErr != nil {
+		reportError(uiState, "access", fmt.Errorf("review of permissions failed: %w", accessErr))
+	}
+	if bookmarksErr != nil {
+		reportError(uiState, "bookmarks", fmt.Errorf("bookmarks can't be loaded: %w", bookmarksErr))
+	}
+	if len(deprecatedErrs) > 0 {
+		reportError(uiState, "deprecated", fmt.Errorf("deprecated resources of %d group-versions are unknown: %w",
+			len(deprecatedErrs), deprecatedErrs[0]))
+	}
+
+	// Open the path given on the command line, a missing field is reported in the status line
+	if uiData.InitialPath != "" {
+		if err := uiState.commands.Run(uiData, uiState, "goto "+uiData.InitialPath); err != nil {
+			setStatusError(uiState, err)
+		}
+	}
+
 	// Set up the app and start it.
-	if err := app.SetRoot(mainLayout, true).Run(); err != nil {
+	if err := app.SetRoot(pages, true).Run(); err != nil {
 		return err
 	}
 
@@ -151,6 +285,9 @@ func RunApp(uiData *UIData) error {
 func populateRootNodeWithResources(
 	apiResourcesRootNode *tview.TreeNode,
 	uiData *UIData,
+	access *ResourceAccess,
+	deprecated map[schema.GroupVersionResource]bool,
+	references *ReferenceIndex,
 	serverPreferredResources []*metav1.APIResourceList,
 ) error {
 	// Build the tree with API groups and resources
@@ -174,14 +311,10 @@ func populateRootNodeWithResources(
 		// Add resources as child nodes to the group node
 		for i := 0; i < len(resources); i++ {
 			resource := resources[i]
-			resourceNode, err := createResourceNodeWithAllFieldsSet(group, &resource, uiData)
+			resourceNode, err := createResourceNodeWithAllFieldsSet(group, &resource, uiData, access, deprecated, references)
 			if err != nil {
 				return err
 			}
-			// no resources
-			if resourceNode == nil {
-				continue
-			}
 			groupNode.AddChild(resourceNode)
 		}
 
@@ -199,6 +332,9 @@ func createResourceNodeWithAllFieldsSet(
 	group *metav1.APIResourceList,
 	resource *metav1.APIResource,
 	uiData *UIData,
+	access *ResourceAccess,
+	deprecated map[schema.GroupVersionResource]bool,
+	references *ReferenceIndex,
 ) (*tview.TreeNode, error) {
 	gv, err := schema.ParseGroupVersion(group.GroupVersion)
 	if err != nil {
@@ -207,13 +343,17 @@ func createResourceNodeWithAllFieldsSet(
 
 	gvr := gv.WithResource(resource.Name)
 
-	paths, err := getPaths(uiData.RestMapper, uiData.OpenAPISchema, gvr)
+	// a resource, that fields can't be loaded for, is kept in the tree with the reason, see :errors
+	paths, fieldMetas, err := getPaths(uiData.RestMapper, uiData.OpenAPISchema, gvr, references)
 	if err != nil {
-		return nil, err
+		slog.Debug("resources", slog.String("gvr", gvr.String()), slog.String("schema-failed", err.Error()))
+		return createResourceErrorNode(gvr, resource, err), nil
 	}
+	// e.g. custom resources with x-kubernetes-preserve-unknown-fields, they're listed in :errors as well
 	if len(paths) == 0 {
-		slog.Debug("resources", slog.String("ignored-group", group.String()))
-		return nil, nil
+		err := fmt.Errorf("the schema of %s has no fields", gvr.GroupVersion().WithKind(resource.Kind))
+		slog.Debug("resources", slog.String("gvr", gvr.String()), slog.String("schema-failed", err.Error()))
+		return createResourceErrorNode(gvr, resource, err), nil
 	}
 
 	// Create internal tree from a given paths
@@ -224,14 +364,15 @@ func createResourceNodeWithAllFieldsSet(
 
 	// Convert internal tree to a tree-view
 	tempNode := tview.NewTreeNode("tmp")
-	populateNodeWithResourceFields(tempNode, rootFieldsNode.Children, &gvr)
+	populateNodeWithResourceFields(tempNode, rootFieldsNode.Children, &gvr, fieldMetas)
 	if len(tempNode.GetChildren()) != 1 {
-		return nil, fmt.Errorf("error when populating fields for tree node")
+		err := fmt.Errorf("error when populating fields for tree node")
+		slog.Debug("resources", slog.String("gvr", gvr.String()), slog.String("schema-failed", err.Error()))
+		return createResourceErrorNode(gvr, resource, err), nil
 	}
 
 	// Fetch the result after conversion
 	resourceNodeTreeView := tempNode.GetChildren()[0]
-	resourceNodeTreeView.SetText(fmt.Sprintf("%s (%s)", resource.Kind, resource.Name))
 
 	// Customize node internal data
 	resourceNodeData, err := extractTreeData(resourceNodeTreeView)
@@ -240,18 +381,63 @@ func createResourceNodeWithAllFieldsSet(
 	}
 	resourceNodeData.nodeType = nodeTypeResource
 	resourceNodeData.gvr = &gvr
+	resourceNodeData.label = fmt.Sprintf("%s (%s)", resource.Kind, resource.Name)
+	resourceNodeData.expandable = false // the kind and the name only, like groups
+	if access != nil {
+		resourceNodeData.access = access
+		resourceNodeData.allowedVerbs, resourceNodeData.forbiddenVerbs, resourceNodeData.unknownVerbs =
+			access.splitVerbs(gv.Group, resource.Name, resource.Verbs)
+	}
+	resourceNodeTreeView.SetText(renderNodeText(resourceNodeData))
+	if deprecated[gvr] {
+		if resourceNodeData.meta == nil {
+			resourceNodeData.meta = &FieldMeta{}
+		}
+		resourceNodeData.meta.deprecated = true
+	}
 	resourceNodeTreeView.SetReference(resourceNodeData)
 
 	return resourceNodeTreeView, nil
 }
 
+// loadDeprecatedResources looks up deprecated resources once per group-version,
+// group-versions, that documents can't be fetched for, are returned as errors
+func loadDeprecatedResources(
+	resolver *SchemaResolver,
+	serverPreferredResources []*metav1.APIResourceList,
+) (map[schema.GroupVersionResource]bool, []error) {
+	deprecated := make(map[schema.GroupVersionResource]bool)
+	var errs []error
+	for _, group := range serverPreferredResources {
+		gv, err := schema.ParseGroupVersion(group.GroupVersion)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		resources, err := resolver.DeprecatedResources(gv)
+		if err != nil {
+			slog.Debug("resources", slog.String("gv", gv.String()), slog.String("deprecated-failed", err.Error()))
+			errs = append(errs, err)
+			continue
+		}
+		for resource := range resources {
+			deprecated[gv.WithResource(resource)] = true
+		}
+	}
+	return deprecated, errs
+}
+
 func populateNodeWithResourceFields(
 	parent *tview.TreeNode,
 	children map[string]*ResourceFieldsNode,
 	gvr *schema.GroupVersionResource,
+	fieldMetas map[string]*FieldMeta,
 ) {
 	if len(children) != 0 {
-		parent.SetText(parent.GetText() + " >")
+		if data, err := extractTreeData(parent); err == nil {
+			data.expandable = true
+			renderNode(parent)
+		}
 		parent.SetExpanded(!parent.IsExpanded())
 	}
 
@@ -262,21 +448,24 @@ func populateNodeWithResourceFields(
 	sort.Strings(keys)
 
 	for _, key := range keys {
-		childNode := tview.NewTreeNode(children[key].Name).SetReference(&TreeData{
+		fieldMeta := fieldMetas[children[key].Path]
+		childData := &TreeData{
 			nodeType: nodeTypeField,
+			label:    children[key].Name,
 			path:     children[key].Path,
 			gvr:      gvr,
-		})
+			meta:     fieldMeta,
+		}
+		childNode := tview.NewTreeNode(renderNodeText(childData)).SetReference(childData)
 		parent.AddChild(childNode)
 		if children[key].Children != nil {
-			populateNodeWithResourceFields(childNode, children[key].Children, gvr)
+			populateNodeWithResourceFields(childNode, children[key].Children, gvr, fieldMetas)
+		}
+		// the recursive type is not visited again, it's expanded on demand
+		if recursion := fieldMeta.getRecursion(); recursion != "" && len(children[key].Children) == 0 {
+			childData.expandable = true
+			childNode.SetText(renderNodeText(childData)).SetExpanded(false)
+			childNode.AddChild(newRecursionNode(gvr, children[key].Path, recursion))
 		}
 	}
 }
-
-func getHelpMenuContent() string {
-	return strings.TrimSpace(`
-[yellow]</term>[-]  Search | [yellow]<:cmd>[-] Command            | [yellow]<ENTER>[-] Select (gr/res) | [yellow]<hjkl>[-]   Navigate |
-[yellow]<ctrl-c>[-] Quit   | [yellow]<TAB>[-]  Focus tree/details | [yellow]<ESC>[-]   Step back       | [yellow]<ARROWS>[-] Navigate |
-`)
-}
@@ -0,0 +1,41 @@
+package apidocs
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCreateResourceNodeWithAllFieldsSet_NoFields(t *testing.T) {
+	// e.g. a custom resource, that preserves unknown fields
+	resources := parseTestOpenAPIResources(t, `{
+  "swagger": "2.0",
+  "info": {"title": "test", "version": "v1"},
+  "paths": {},
+  "definitions": {
+    "io.k8s.api.apps.v1.Deployment": {
+      "type": "object",
+      "x-kubernetes-preserve-unknown-fields": true,
+      "x-kubernetes-group-version-kind": [{"group": "apps", "version": "v1", "kind": "Deployment"}]
+    }
+  }
+}`)
+	uiData := &UIData{RestMapper: newTestRESTMapper(), OpenAPISchema: resources}
+	group := &metav1.APIResourceList{GroupVersion: "apps/v1"}
+	resource := &metav1.APIResource{Name: "deployments", Kind: "Deployment"}
+
+	node, err := createResourceNodeWithAllFieldsSet(group, resource, uiData, nil, nil, NewReferenceIndex())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if node == nil {
+		t.Fatal("Expected the resource to be kept in the tree")
+	}
+	data, err := extractTreeData(node)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !data.IsNodeType(nodeTypeError) || data.err == nil || data.path != "deployments" {
+		t.Fatalf("Expected an error node, got %+v", data)
+	}
+}
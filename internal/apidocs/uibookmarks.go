@@ -0,0 +1,113 @@
+package apidocs
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const (
+	pageBookmarks = "bookmarks"
+	bookmarkSign  = "★"
+)
+
+// loadBookmarksFromConfigDir returns bookmarks, that are empty, when they can't be loaded,
+// the error is reported in the status line, see RunApp
+func loadBookmarksFromConfigDir() (*Bookmarks, error) {
+	dir, err := getConfigDir()
+	if err != nil {
+		return &Bookmarks{}, err
+	}
+	return LoadBookmarks(filepath.Join(dir, bookmarksFileName))
+}
+
+// markBookmarkedNodes decorates nodes that are bookmarked
+func markBookmarkedNodes(uiState *UIState) {
+	for i := range uiState.bookmarks.Items {
+		b := &uiState.bookmarks.Items[i]
+		if node := uiState.treeLinks.FindNode(b.GVR(), b.Path); node != nil {
+			setBookmarkDecoration(node, true)
+		}
+	}
+}
+
+func setBookmarkDecoration(node *tview.TreeNode, bookmarked bool) {
+	if data, err := extractTreeData(node); err == nil {
+		data.bookmarked = bookmarked
+		renderNode(node)
+	}
+}
+
+func toggleBookmark(uiState *UIState, node *tview.TreeNode) error {
+	data, err := extractTreeData(node)
+	if err != nil {
+		return err
+	}
+	if !data.IsNodeType(nodeTypeResource, nodeTypeField) {
+		return nil
+	}
+	added, err := uiState.bookmarks.Toggle(*data.gvr, data.path)
+	if err != nil {
+		return err
+	}
+	// the node may be a clone in a filtered tree, decorate the original one as well
+	setBookmarkDecoration(node, added)
+	if original := uiState.treeLinks.FindNode(*data.gvr, data.path); original != nil {
+		setBookmarkDecoration(original, added)
+	}
+	return nil
+}
+
+// showBookmarks lists bookmarks, ENTER jumps to the bookmarked node, the remove key ('d') removes a bookmark
+func showBookmarks(uiData *UIData, uiState *UIState) {
+	list := tview.NewList()
+	list.SetUseStyleTags(false, false)
+	list.ShowSecondaryText(false)
+	list.SetBorder(true)
+	list.SetTitle(fmt.Sprintf("Bookmarks (ENTER: jump, %s: delete, ESC: close)", uiState.keymap.KeysFor(actionRemove)))
+
+	fillList := func() {
+		list.Clear()
+		if len(uiState.bookmarks.Items) == 0 {
+			list.AddItem("(no bookmarks, press 'm' on a field to add one)", "", 0, nil)
+			return
+		}
+		for i := range uiState.bookmarks.Items {
+			b := uiState.bookmarks.Items[i]
+			list.AddItem(b.String(), "", 0, func() {
+				hideModal(uiState, pageBookmarks)
+				node := uiState.treeLinks.FindNode(b.GVR(), b.Path)
+				if node == nil {
+					reportError(uiState, "bookmarks", fmt.Errorf("bookmark not found: %s", b.String()))
+					return
+				}
+				reportError(uiState, "bookmarks", navigateToNode(uiData, uiState, node))
+			})
+		}
+	}
+	fillList()
+
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if uiState.keymap.Matches(actionRemove, event) && len(uiState.bookmarks.Items) > 0 {
+			i := list.GetCurrentItem()
+			b := uiState.bookmarks.Items[i]
+			if err := uiState.bookmarks.Remove(i); err != nil {
+				reportError(uiState, "bookmarks", err)
+				return nil
+			}
+			if node := uiState.treeLinks.FindNode(b.GVR(), b.Path); node != nil {
+				setBookmarkDecoration(node, false)
+			}
+			fillList()
+			return nil
+		}
+		return event
+	})
+	list.SetDoneFunc(func() {
+		hideModal(uiState, pageBookmarks)
+	})
+
+	showModal(uiState, pageBookmarks, list, 100, min(len(uiState.bookmarks.Items), 20)+3)
+}
@@ -0,0 +1,103 @@
+package apidocs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const breadcrumbSeparator = " › "
+
+// updateBreadcrumb shows the location of a node, e.g. 'API Resources › apps/v1 › Deployment › spec › template'
+func updateBreadcrumb(uiState *UIState, node *tview.TreeNode) {
+	var chain []*tview.TreeNode
+	for n := getOriginalNode(uiState, node); n != nil; n = uiState.treeLinks.ParentMap[n] {
+		chain = append([]*tview.TreeNode{n}, chain...)
+	}
+	uiState.breadcrumbNodes = chain
+	uiState.breadcrumbIndex = len(chain) - 1
+	renderBreadcrumb(uiState)
+}
+
+// renderBreadcrumb draws segments as regions, so they may be clicked, the selected segment is shown reversed
+func renderBreadcrumb(uiState *UIState) {
+	focused := uiState.app.GetFocus() == uiState.breadcrumb
+	parts := make([]string, 0, len(uiState.breadcrumbNodes))
+	for i, n := range uiState.breadcrumbNodes {
+		label := tview.Escape(getBreadcrumbLabel(n))
+		if focused && i == uiState.breadcrumbIndex {
+			label = fmt.Sprintf("[::r]%s[::-]", label)
+		}
+		parts = append(parts, fmt.Sprintf(`["%d"]%s[""]`, i, label))
+	}
+	uiState.breadcrumb.Highlight()
+	uiState.breadcrumb.SetText(strings.Join(parts, breadcrumbSeparator))
+}
+
+func getBreadcrumbLabel(node *tview.TreeNode) string {
+	data, err := extractTreeData(node)
+	if err != nil {
+		return getNodePlainText(node)
+	}
+	text := strings.TrimSuffix(getNodePlainText(node), " >")
+	switch data.nodeType {
+	case nodeTypeRoot:
+		return "API Resources"
+	case nodeTypeResource, nodeTypeError:
+		// 'Deployment (deployments)' -> 'Deployment'
+		kind, _, _ := strings.Cut(text, " (")
+		return kind
+	case nodeTypeField:
+		return data.path[strings.LastIndex(data.path, ".")+1:]
+	case nodeTypeDefinition:
+		// 'io.k8s.api.core.v1.Container' -> 'Container'
+		return getShortRefName(data.definition)
+	default:
+		return text
+	}
+}
+
+func jumpToBreadcrumb(uiData *UIData, uiState *UIState, i int) error {
+	if i < 0 || i >= len(uiState.breadcrumbNodes) {
+		return nil
+	}
+	setFocusOn(uiState, uiState.apiResourcesTreeView)
+	return navigateToNode(uiData, uiState, uiState.breadcrumbNodes[i])
+}
+
+func setupListenersForBreadcrumb(uiData *UIData, uiState *UIState) error {
+	// mouse clicks highlight a region
+	uiState.breadcrumb.SetHighlightedFunc(func(added, _, _ []string) {
+		if len(added) == 0 {
+			return
+		}
+		i, err := strconv.Atoi(added[0])
+		if err != nil {
+			return
+		}
+		reportError(uiState, "breadcrumb", jumpToBreadcrumb(uiData, uiState, i))
+	})
+
+	// keyboard: collapse/expand keys (h/l and arrows) select a segment, ENTER jumps,
+	// step back and switch focus keys (ESC/TAB) get back to the tree
+	uiState.breadcrumb.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case uiState.keymap.Matches(actionCollapse, event):
+			uiState.breadcrumbIndex = max(uiState.breadcrumbIndex-1, 0)
+			renderBreadcrumb(uiState)
+		case uiState.keymap.Matches(actionExpand, event):
+			uiState.breadcrumbIndex = min(uiState.breadcrumbIndex+1, len(uiState.breadcrumbNodes)-1)
+			renderBreadcrumb(uiState)
+		case event.Key() == tcell.KeyEnter:
+			reportError(uiState, "breadcrumb", jumpToBreadcrumb(uiData, uiState, uiState.breadcrumbIndex))
+		case uiState.keymap.Matches(actionStepBack, event) || uiState.keymap.Matches(actionSwitchFocus, event):
+			setFocusOn(uiState, uiState.apiResourcesTreeView)
+			updateBreadcrumb(uiState, uiState.apiResourcesTreeView.GetCurrentNode())
+		}
+		return nil
+	})
+	return nil
+}
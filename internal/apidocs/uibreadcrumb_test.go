@@ -0,0 +1,47 @@
+package apidocs
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func TestBreadcrumb_ReboundKeys(t *testing.T) {
+	keymap, err := NewKeymap(map[string]KeyList{"collapse": {"J"}, "expand": {"K"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	uiState := &UIState{
+		app:        tview.NewApplication(),
+		breadcrumb: tview.NewTextView(),
+		keymap:     keymap,
+		breadcrumbNodes: []*tview.TreeNode{
+			tview.NewTreeNode("API Resources >").SetReference(&TreeData{nodeType: nodeTypeRoot}),
+			tview.NewTreeNode("apps/v1").SetReference(&TreeData{nodeType: nodeTypeGroup}),
+			tview.NewTreeNode("Deployment (deployments)").SetReference(&TreeData{nodeType: nodeTypeResource}),
+		},
+		breadcrumbIndex: 2,
+	}
+	if err := setupListenersForBreadcrumb(&UIData{}, uiState); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	handler := uiState.breadcrumb.InputHandler()
+	press := func(r rune) {
+		handler(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone), func(tview.Primitive) {})
+	}
+
+	press('J')
+	if uiState.breadcrumbIndex != 1 {
+		t.Fatalf("Expected the rebound collapse key to select the previous segment, got %d", uiState.breadcrumbIndex)
+	}
+	// the default keys are unbound
+	press('h')
+	if uiState.breadcrumbIndex != 1 {
+		t.Fatalf("Expected 'h' to be ignored, got %d", uiState.breadcrumbIndex)
+	}
+	press('K')
+	if uiState.breadcrumbIndex != 2 {
+		t.Fatalf("Expected the rebound expand key to select the next segment, got %d", uiState.breadcrumbIndex)
+	}
+}
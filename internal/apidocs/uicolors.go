@@ -1,15 +1,12 @@
 package apidocs
 
 import (
+	"strings"
+
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
-var (
-	focusColor   = tcell.ColorSteelBlue
-	noFocusColor = tcell.ColorLightGray
-)
-
 // Helper function to reset all node colors
 func resetNodeColors(node *tview.TreeNode) {
 	if node == nil {
@@ -19,20 +16,111 @@ func resetNodeColors(node *tview.TreeNode) {
 	if err != nil {
 		return
 	}
+	color := theme.field
 	switch data.nodeType {
 	case nodeTypeRoot:
-		node.SetColor(tcell.ColorYellow)
+		color = theme.root
 	case nodeTypeGroup:
-		node.SetColor(tcell.ColorGreen)
-	case nodeTypeResource:
-		node.SetColor(tcell.ColorSteelBlue)
-	case nodeTypeField:
-		node.SetColor(tcell.ColorLightGray)
-	default:
-		node.SetColor(tcell.ColorLightGray)
+		color = theme.group
+	case nodeTypeResource, nodeTypeDefinition:
+		color = theme.resource
+	case nodeTypeUsage, nodeTypeRecursion:
+		color = theme.annotation
+	case nodeTypeError:
+		color = theme.alert
+	}
+
+	// deprecated fields and resources are struck-through in a warning color
+	node.SetTextStyle(node.GetTextStyle().StrikeThrough(data.meta.isDeprecated()))
+	if data.meta.isDeprecated() {
+		color = theme.deprecated
+	}
+	node.SetColor(color)
+	// the selected node is shown in the node color on the background color, which are the same in monochrome
+	if theme.monochrome {
+		node.SetSelectedTextStyle(node.GetTextStyle().Reverse(true))
+	} else {
+		node.SetSelectedTextStyle(tcell.StyleDefault.Foreground(theme.background).Background(color))
 	}
 
 	for _, child := range node.GetChildren() {
 		resetNodeColors(child)
 	}
 }
+
+// applyTheme restyles views and nodes, after the theme was switched by a command
+func applyTheme(uiData *UIData, uiState *UIState) {
+	theme.apply()
+
+	for _, box := range []interface {
+		SetBackgroundColor(tcell.Color) *tview.Box
+	}{uiState.pages, uiState.mainLayout, uiState.apiResourcesViewsLayout} {
+		box.SetBackgroundColor(theme.background)
+	}
+	for _, view := range []*tview.TextView{uiState.helpMenu, uiState.apiResourcesDetailsView, uiState.breadcrumb, uiState.statusLine} {
+		view.SetBackgroundColor(theme.background)
+		view.SetTextColor(theme.text)
+		view.SetTitleColor(theme.text)
+	}
+	uiState.helpMenu.SetBorderColor(theme.noFocusBorder)
+	uiState.helpMenu.SetText(getHelpMenuContent(uiState.keymap))
+	uiState.apiResourcesTreeView.SetBackgroundColor(theme.background)
+	uiState.apiResourcesTreeView.SetGraphicsColor(theme.graphics)
+	uiState.apiResourcesTreeView.SetTitleColor(theme.text)
+	uiState.cmdInput.SetFieldTextColor(theme.text)
+	uiState.cmdInput.SetBackgroundColor(theme.background)
+	uiState.cmdInput.SetLabelColor(theme.accent)
+	uiState.cmdInput.SetFieldBackgroundColor(theme.background)
+	uiState.cmdInput.SetTitleColor(theme.text)
+
+	// decorations of nodes are color tags in their texts, clones in a filtered tree share the data of the originals
+	renderNodes(uiState.apiResourcesRootNode)
+	if root := uiState.apiResourcesTreeView.GetRoot(); uiState.isInFilter && root != nil {
+		renderNodes(root)
+	}
+	resetNodeColors(uiState.apiResourcesRootNode)
+	renderBreadcrumb(uiState)
+
+	// the details of the current node have color tags as well, pages opened by links are dropped
+	if node := uiState.apiResourcesTreeView.GetCurrentNode(); node != nil {
+		reportError(uiState, "theme", showNodeDetails(uiData, uiState, node))
+	}
+	setFocusOn(uiState, uiState.app.GetFocus())
+}
+
+func renderNodes(node *tview.TreeNode) {
+	renderNode(node)
+	for _, child := range node.GetChildren() {
+		renderNodes(child)
+	}
+}
+
+// renderNode sets the text of a decorated node, nodes without a label have plain texts, that are kept as is
+func renderNode(node *tview.TreeNode) {
+	if data, err := extractTreeData(node); err == nil && data.label != "" {
+		node.SetText(renderNodeText(data))
+	}
+}
+
+// renderNodeText renders the label of a node with its decorations in the colors of the current theme:
+// the collection annotation and the '>' marker of fields, access verbs of resources, and the bookmark sign
+func renderNodeText(data *TreeData) string {
+	parts := []string{data.label}
+	if data.IsNodeType(nodeTypeField) {
+		if annotation := data.meta.getCollectionAnnotation(); annotation != "" {
+			parts = append(parts, theme.colorize(theme.annotation, annotation))
+		}
+	}
+	if data.expandable {
+		parts = append(parts, ">")
+	}
+	if data.access != nil {
+		if tags := getAccessTags(data.allowedVerbs, data.forbiddenVerbs, data.unknownVerbs); tags != "" {
+			parts = append(parts, tags)
+		}
+	}
+	if data.bookmarked {
+		parts = append(parts, theme.colorize(theme.accent, bookmarkSign))
+	}
+	return strings.Join(parts, " ")
+}
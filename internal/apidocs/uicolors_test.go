@@ -0,0 +1,69 @@
+package apidocs
+
+import (
+	"testing"
+
+	"github.com/rivo/tview"
+)
+
+func TestRenderNodeText_Field(t *testing.T) {
+	// colors of these themes have no aliases (e.g. gray and grey), so the color tags are stable
+	theme = themes[themeSolarized]
+	defer func() { theme = themes[themeDark] }()
+
+	data := &TreeData{
+		nodeType:   nodeTypeField,
+		label:      "containers",
+		expandable: true,
+		bookmarked: true,
+		meta:       &FieldMeta{isArray: true, listType: "map", listMapKeys: []string{"name"}},
+	}
+	node := tview.NewTreeNode(renderNodeText(data)).SetReference(data)
+	expected := "containers " + theme.colorize(theme.annotation, "{list=map(name)}") + " > " +
+		theme.colorize(theme.accent, bookmarkSign)
+	if node.GetText() != expected {
+		t.Fatalf("Expected %q, got %q", expected, node.GetText())
+	}
+
+	// decorations follow the theme, the label is kept as is
+	theme = themes[themeMonochrome]
+	renderNodes(node)
+	expected = "containers " + theme.colorize(theme.annotation, "{list=map(name)}") + " > " +
+		theme.colorize(theme.accent, bookmarkSign)
+	if node.GetText() != expected {
+		t.Fatalf("Expected %q, got %q", expected, node.GetText())
+	}
+	if plain := getNodePlainText(node); plain != "containers" {
+		t.Fatalf("Unexpected plain text: %q", plain)
+	}
+
+	setBookmarkDecoration(node, false)
+	if node.GetText() != "containers "+theme.colorize(theme.annotation, "{list=map(name)}")+" >" {
+		t.Fatalf("Unexpected text without the bookmark: %q", node.GetText())
+	}
+}
+
+func TestRenderNodeText_Resource(t *testing.T) {
+	theme = themes[themeMonochrome]
+	defer func() { theme = themes[themeDark] }()
+
+	data := &TreeData{
+		nodeType:     nodeTypeResource,
+		label:        "Deployment (deployments)",
+		access:       &ResourceAccess{},
+		allowedVerbs: []string{"get", "list"},
+		// the collection annotation is shown for fields only
+		meta: &FieldMeta{listType: "atomic"},
+	}
+	expected := "Deployment (deployments) " + getAccessTags(data.allowedVerbs, nil, nil)
+	if text := renderNodeText(data); text != expected {
+		t.Fatalf("Expected %q, got %q", expected, text)
+	}
+
+	// nodes without a label keep their plain texts
+	group := tview.NewTreeNode("apps/v1").SetReference(&TreeData{nodeType: nodeTypeGroup})
+	renderNodes(group)
+	if group.GetText() != "apps/v1" || getNodePlainText(group) != "apps/v1" {
+		t.Fatalf("Unexpected group text: %q", group.GetText())
+	}
+}
@@ -0,0 +1,47 @@
+package apidocs
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// showCopyMenu offers representations of the selected field, the chosen one is copied to the clipboard
+func showCopyMenu(uiState *UIState, data *TreeData) {
+	segments := getFieldPathSegments(uiState.treeLinks, data)
+	dottedPath := getDottedPath(segments)
+	if dottedPath == "" {
+		dottedPath = data.path
+	}
+	explainCmd := fmt.Sprintf("kubectl explain %s --api-version=%s", data.path, data.gvr.GroupVersion().String())
+	details := strings.TrimSpace(uiState.apiResourcesDetailsView.GetText(true))
+
+	list := tview.NewList()
+	list.SetUseStyleTags(false, false)
+	list.SetBorder(true)
+	list.SetTitle("Copy to clipboard")
+	addItem := func(title, value, preview string, shortcut rune) {
+		list.AddItem(title, preview, shortcut, func() {
+			copyToClipboard(uiState, value)
+			hideModal(uiState, pageCopy)
+		})
+	}
+	addItem("Path", dottedPath, dottedPath, 'p')
+	addItem("kubectl explain", explainCmd, explainCmd, 'e')
+	addItem("JSONPath", getJSONPath(segments), getJSONPath(segments), 'j')
+	addItem("Details", details, fmt.Sprintf("%d lines", strings.Count(details, "\n")+1), 'd')
+	list.SetDoneFunc(func() {
+		hideModal(uiState, pageCopy)
+	})
+
+	showModal(uiState, pageCopy, list, 80, list.GetItemCount()*2+2)
+}
+
+// copyToClipboard posts a text to the system clipboard using the OSC 52 escape sequence,
+// so it works over SSH as well (the terminal has to support it)
+func copyToClipboard(uiState *UIState, text string) {
+	slog.Debug("clipboard", slog.Int("copied-bytes", len(text)))
+	uiState.screen.SetClipboard([]byte(text))
+}
@@ -0,0 +1,129 @@
+package apidocs
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/rivo/tview"
+	"k8s.io/kube-openapi/pkg/util/proto"
+)
+
+const (
+	definitionsNodeText = "Definitions"
+	// maxUsagesInDetails limits the "used by" list in the details, the tree lists all of them
+	maxUsagesInDetails = 20
+)
+
+// createDefinitionsNode lists OpenAPI definitions with their fields, and resources and fields using them,
+// it's opened in preview by ENTER, like a group
+func createDefinitionsNode(references *ReferenceIndex) *tview.TreeNode {
+	definitionsNode := tview.NewTreeNode(definitionsNodeText).
+		SetReference(&TreeData{nodeType: nodeTypeGroup}).
+		SetExpanded(false)
+	for _, name := range references.Names() {
+		definitionsNode.AddChild(createDefinitionNode(references, name))
+	}
+	return definitionsNode
+}
+
+func createDefinitionNode(references *ReferenceIndex, name string) *tview.TreeNode {
+	definitionNode := tview.NewTreeNode(name + " >").
+		SetReference(&TreeData{nodeType: nodeTypeDefinition, definition: name}).
+		SetExpanded(false)
+	for _, field := range getDefinitionFields(references.Schema(name)) {
+		definitionNode.AddChild(tview.NewTreeNode(field).SetReference(&TreeData{
+			nodeType:   nodeTypeDefinitionField,
+			definition: name,
+			path:       field,
+		}))
+	}
+
+	usages := references.Usages(name)
+	usedByNode := tview.NewTreeNode(fmt.Sprintf("used by (%d) >", len(usages))).
+		SetReference(&TreeData{nodeType: nodeTypeUsage}).
+		SetExpanded(false)
+	for _, usage := range usages {
+		gvr := usage.GVR
+		usedByNode.AddChild(tview.NewTreeNode(getUsageText(usage)).SetReference(&TreeData{
+			nodeType: nodeTypeUsage,
+			gvr:      &gvr,
+			path:     usage.Path,
+		}))
+	}
+	definitionNode.AddChild(usedByNode)
+	return definitionNode
+}
+
+// getUsageText returns a path with the group-version, e.g. 'deployments.spec.template (apps/v1)'
+func getUsageText(usage TypeUsage) string {
+	return fmt.Sprintf("%s (%s)", usage.Path, usage.GVR.GroupVersion())
+}
+
+// explainDefinition renders a definition, or a field of it, from the OpenAPI v3 document of a resource using it
+func explainDefinition(uiState *UIState, data *TreeData) {
+	usages := uiState.references.Usages(data.definition)
+	if len(usages) == 0 {
+		return
+	}
+	fs, err := uiState.schemaResolver.ResolveDefinition(usages[0].GVR, data.definition, data.path)
+	if err != nil {
+		slog.Debug("schema", slog.String("definition", data.definition), slog.String("resolve-failed", err.Error()))
+		uiState.apiResourcesDetailsView.SetText(tview.Escape(fmt.Sprintf("%s\n\n%s", data.definition, err)))
+		return
+	}
+
+	links := &detailsLinks{}
+	text := renderDefinitionDetails(fs, data.definition, data.path, links) + getSchemaDetails(data, fs)
+	if data.IsNodeType(nodeTypeDefinition) {
+		text += getUsagesDescription(usages)
+	}
+	showDetailsPage(uiState, &detailsPage{fs: fs, text: text, links: links})
+}
+
+// getUsagesDescription renders the "used by" list of a definition, long lists are cut
+func getUsagesDescription(usages []TypeUsage) string {
+	text := "\n" + detailsLabel("USED BY:") + "\n"
+	for i, usage := range usages {
+		if i == maxUsagesInDetails {
+			text += fmt.Sprintf("    ... and %d more, see the tree\n", len(usages)-i)
+			break
+		}
+		text += "    " + tview.Escape(getUsageText(usage)) + "\n"
+	}
+	return text
+}
+
+// jumpToUsage opens a resource or a field, that uses a definition, in the tree
+func jumpToUsage(uiData *UIData, uiState *UIState, data *TreeData) error {
+	if data.gvr == nil {
+		return nil
+	}
+	node := uiState.treeLinks.FindNode(*data.gvr, data.path)
+	if node == nil {
+		return fmt.Errorf("no such path: %s", data.path)
+	}
+	return navigateToNode(uiData, uiState, node)
+}
+
+// getSelectedDefinition returns a definition of the selected node: of a resource, a field, or a field of a definition
+func getSelectedDefinition(uiState *UIState) (string, error) {
+	data, err := extractTreeData(uiState.apiResourcesTreeView.GetCurrentNode())
+	if err != nil {
+		return "", err
+	}
+	var name string
+	switch data.nodeType {
+	case nodeTypeResource, nodeTypeField:
+		name = data.meta.getDefinition()
+	case nodeTypeDefinition:
+		name = data.definition
+	case nodeTypeDefinitionField:
+		if kind, ok := uiState.references.Schema(data.definition).(*proto.Kind); ok {
+			name = getSchemaReference(kind.Fields[data.path])
+		}
+	}
+	if name == "" {
+		return "", fmt.Errorf("the selected node has no definition, set a name, e.g. :usages LabelSelector")
+	}
+	return name, nil
+}
@@ -0,0 +1,76 @@
+package apidocs
+
+// detailsPage is a schema rendered in the details pane, with links to the definitions it refers to
+type detailsPage struct {
+	fs    *FieldSchema
+	text  string
+	links *detailsLinks
+
+	// the highlighted link and the scroll position, restored when getting back to the page
+	link int
+	row  int
+}
+
+// showDetailsPage renders a page in the details pane, the first link is highlighted
+func showDetailsPage(uiState *UIState, page *detailsPage) {
+	uiState.detailsPage = page
+	view := uiState.apiResourcesDetailsView
+	view.SetText(page.text)
+	view.Highlight()
+	if len(page.links.refNames) > 0 {
+		view.Highlight(getLinkRegion(page.link))
+	}
+	view.ScrollTo(page.row, 0)
+}
+
+// resetDetailsPages drops the current page and the pages visited by links, e.g. when another node is selected
+func resetDetailsPages(uiState *UIState) {
+	uiState.detailsPage = nil
+	uiState.detailsBack = nil
+	uiState.apiResourcesDetailsView.Highlight()
+}
+
+// moveDetailsLink highlights the next (or the previous) link of the page, wrapping around
+func moveDetailsLink(uiState *UIState, step int) {
+	page := uiState.detailsPage
+	if page == nil || len(page.links.refNames) == 0 {
+		return
+	}
+	count := len(page.links.refNames)
+	page.link = ((page.link+step)%count + count) % count
+	uiState.apiResourcesDetailsView.Highlight(getLinkRegion(page.link)).ScrollToHighlight()
+}
+
+// followDetailsLink renders the definition of the highlighted link, the current page is kept for getting back
+func followDetailsLink(uiState *UIState) error {
+	page := uiState.detailsPage
+	if page == nil || len(page.links.refNames) == 0 {
+		return nil
+	}
+	fs, err := resolveDefinitionSchema(page.fs.doc, page.fs.GVK, page.links.refNames[page.link])
+	if err != nil {
+		return err
+	}
+	page.row, _ = uiState.apiResourcesDetailsView.GetScrollOffset()
+	uiState.detailsBack = append(uiState.detailsBack, page)
+
+	links := &detailsLinks{}
+	showDetailsPage(uiState, &detailsPage{
+		fs:    fs,
+		text:  renderDefinitionDetails(fs, fs.RefName, "", links) + getSchemaDetails(&TreeData{}, fs),
+		links: links,
+	})
+	return nil
+}
+
+// backDetailsLink gets back to the page, the current definition was opened from, it returns false on the first page
+func backDetailsLink(uiState *UIState) bool {
+	if len(uiState.detailsBack) == 0 {
+		return false
+	}
+	last := len(uiState.detailsBack) - 1
+	page := uiState.detailsBack[last]
+	uiState.detailsBack = uiState.detailsBack[:last]
+	showDetailsPage(uiState, page)
+	return true
+}
@@ -0,0 +1,59 @@
+package apidocs
+
+import (
+	"fmt"
+
+	"github.com/rivo/tview"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	pageErrors = "errors"
+	// errorSign marks resources, that fields can't be loaded for
+	errorSign = "✗"
+)
+
+// createResourceErrorNode creates a node of a resource, that fields can't be loaded for, the reason is shown in the text
+func createResourceErrorNode(gvr schema.GroupVersionResource, resource *metav1.APIResource, err error) *tview.TreeNode {
+	text := fmt.Sprintf("%s (%s) %s %s", resource.Kind, resource.Name, errorSign, tview.Escape(err.Error()))
+	return tview.NewTreeNode(text).SetReference(&TreeData{
+		nodeType: nodeTypeError,
+		path:     resource.Name,
+		gvr:      &gvr,
+		err:      err,
+	})
+}
+
+func getResourceErrorDescription(data *TreeData) string {
+	return tview.Escape(fmt.Sprintf("%s (%s)\n\nFields of the resource can't be loaded:\n%v", data.path, data.gvr.GroupVersion(), data.err))
+}
+
+// showErrors lists resources, that fields can't be loaded for, ENTER jumps to the chosen one
+func showErrors(uiData *UIData, uiState *UIState) {
+	list := tview.NewList()
+	list.SetUseStyleTags(false, false)
+	list.ShowSecondaryText(false)
+	list.SetBorder(true)
+	list.SetTitle("Errors (ENTER: jump, ESC: close)")
+
+	nodes := uiState.treeLinks.ErrorNodes
+	if len(nodes) == 0 {
+		list.AddItem("(no errors)", "", 0, nil)
+	}
+	for _, node := range nodes {
+		data, err := extractTreeData(node)
+		if err != nil {
+			continue
+		}
+		list.AddItem(fmt.Sprintf("%s  %s: %v", data.gvr.GroupVersion(), data.path, data.err), "", 0, func() {
+			hideModal(uiState, pageErrors)
+			reportError(uiState, "errors", navigateToNode(uiData, uiState, node))
+		})
+	}
+	list.SetDoneFunc(func() {
+		hideModal(uiState, pageErrors)
+	})
+
+	showModal(uiState, pageErrors, list, 120, min(len(nodes), 20)+3)
+}
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"fmt"
 	"log/slog"
+	"strings"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func setupListeners(
@@ -26,7 +28,11 @@ func setupListeners(
 	if err != nil {
 		return err
 	}
-	err = setupListenersForCmdInput(uiState)
+	err = setupListenersForCmdInput(uiData, uiState)
+	if err != nil {
+		return err
+	}
+	err = setupListenersForBreadcrumb(uiData, uiState)
 	if err != nil {
 		return err
 	}
@@ -34,77 +40,69 @@ func setupListeners(
 }
 
 func setupListenersForResourcesTreeView(uiData *UIData, uiState *UIState) error {
-	// To handle errors inside closures
-	var listenersErr error
-
-	// Stack to handle navigation back
-	var navigationStack []*tview.TreeNode
-	navigationStack = append(navigationStack, uiState.apiResourcesRootNode)
-
-	// Add key event handler for toggling node expansion
-	// Handle <ENTER>
-	uiState.apiResourcesTreeView.SetSelectedFunc(func(node *tview.TreeNode) {
-		if node == nil {
-			return
+	// Handle event keys bound in the keymap: tab/h/l/ESC etc...
+	uiState.apiResourcesTreeView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Handle <ENTER>, it's not set as the selected func, since that is called by a single mouse click too
+		if event.Key() == tcell.KeyEnter {
+			reportError(uiState, "tree", selectNode(uiData, uiState, uiState.apiResourcesTreeView.GetCurrentNode()))
+			return nil
 		}
 
-		// open subview with a subtree
-		data, err := extractTreeData(node)
-		if err != nil {
-			listenersErr = err
-			return
+		// switch focus between views (TAB by default)
+		if uiState.keymap.Matches(actionSwitchFocus, event) {
+			setFocusOn(uiState, uiState.apiResourcesDetailsView) // Switch focus to the DetailsView
+			return nil
 		}
 
-		if data.IsNodeType(nodeTypeGroup, nodeTypeResource) {
-			// not in preview, add to view-stack
-			if !data.inPreview {
-				err := setInPreview(node, true)
-				if err != nil {
-					listenersErr = err
-					return
-				}
-				navigationStack = append(navigationStack, node)
-				uiState.apiResourcesTreeView.SetRoot(node).SetCurrentNode(node)
-				node.SetExpanded(true)
-			} else {
-				node.SetExpanded(!node.IsExpanded())
-			}
-		} else if data.IsNodeType(nodeTypeRoot) {
-			// expand/collapse all groups
-			for _, nc := range node.GetChildren() {
-				nc.SetExpanded(!nc.IsExpanded())
-			}
-		} else {
-			// just expand subtree
-			node.SetExpanded(!node.IsExpanded())
+		// H/L, zM/zR (by default) -> collapse/expand the subtree, a numeric prefix limits the depth, e.g. 3L
+		if uiState.keymap.Matches(actionExpandAll, event) {
+			setSubtreeExpanded(uiState.apiResourcesTreeView.GetCurrentNode(), true, uiState.keymap.Count())
+			return nil
 		}
-	})
-	if listenersErr != nil {
-		return listenersErr
-	}
-
-	// Handle event keys: tab/h/l/ESC etc...
-	uiState.apiResourcesTreeView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		// Handle TAB key to switch focus between views
-		if event.Key() == tcell.KeyTab {
-			setFocusOn(uiState, uiState.apiResourcesDetailsView) // Switch focus to the DetailsView
+		if uiState.keymap.Matches(actionCollapseAll, event) {
+			setSubtreeExpanded(uiState.apiResourcesTreeView.GetCurrentNode(), false, 0)
 			return nil
 		}
 
-		// h/l -> collapse/expand
-		// left-arrow/right-arrow -> collapse/expand
+		// h/l, left-arrow/right-arrow (by default) -> collapse/expand
 		// NOTE: expand fields only, ignore groups and resources (they're managed by ENTER)
-		if (event.Key() == tcell.KeyRune && event.Rune() == 'h') || event.Key() == tcell.KeyLeft {
-			listenersErr = expandCollapseHJKL(uiState, false)
+		if uiState.keymap.Matches(actionCollapse, event) {
+			reportError(uiState, "tree", expandCollapseHJKL(uiState, false))
+			return nil
+		}
+		if uiState.keymap.Matches(actionExpand, event) {
+			reportError(uiState, "tree", expandCollapseHJKL(uiState, true))
+			return nil
+		}
+
+		// copy path/explain/JSONPath/details to the clipboard
+		if uiState.keymap.Matches(actionCopy, event) {
+			data, err := extractTreeData(uiState.apiResourcesTreeView.GetCurrentNode())
+			if err != nil {
+				reportError(uiState, "tree", err)
+				return nil
+			}
+			if data.IsNodeType(nodeTypeResource, nodeTypeField) {
+				showCopyMenu(uiState, data)
+			}
 			return nil
 		}
-		if (event.Key() == tcell.KeyRune && event.Rune() == 'l') || event.Key() == tcell.KeyRight {
-			listenersErr = expandCollapseHJKL(uiState, true)
+
+		// query expressions for the selected field
+		if uiState.keymap.Matches(actionQuery, event) {
+			data, err := extractTreeData(uiState.apiResourcesTreeView.GetCurrentNode())
+			if err != nil {
+				reportError(uiState, "tree", err)
+				return nil
+			}
+			if data.IsNodeType(nodeTypeResource, nodeTypeField) {
+				showQueryMenu(uiState, data)
+			}
 			return nil
 		}
 
 		// back to the root (step back) by ESC
-		if event.Key() == tcell.KeyEscape && (len(navigationStack) > 1 || uiState.isInFilter) {
+		if uiState.keymap.Matches(actionStepBack, event) && (len(uiState.navigationStack) > 1 || uiState.isInFilter) {
 			// restore original layout, drop filtered tree
 			if uiState.isInFilter {
 				uiState.isInFilter = false
@@ -114,76 +112,198 @@ func setupListenersForResourcesTreeView(uiData *UIData, uiState *UIState) error
 				return nil
 			}
 
-			// a node, that was used for preview, we need to clear the flag
-			cur := navigationStack[len(navigationStack)-1]
-			err := setInPreview(cur, false)
-			if err != nil {
-				listenersErr = err
-				return nil
-			}
-			data, err := extractTreeData(cur)
+			cur, err := popPreview(uiState)
 			if err != nil {
-				listenersErr = err
+				reportError(uiState, "tree", err)
 				return nil
 			}
-			// don't need to expand the resource, we need just its name
-			if data.IsNodeType(nodeTypeResource) {
-				cur.SetExpanded(false)
-			}
-			// always expand groups
-			if data.IsNodeType(nodeTypeGroup) {
-				cur.SetExpanded(true)
-			}
+			uiState.apiResourcesTreeView.SetCurrentNode(cur)
+			updateBreadcrumb(uiState, cur)
+			return nil
+		}
 
-			navigationStack = navigationStack[:len(navigationStack)-1]
-			prevNode := navigationStack[len(navigationStack)-1]
-			uiState.apiResourcesTreeView.SetRoot(prevNode).SetCurrentNode(cur)
+		// history: back/forward
+		if uiState.keymap.Matches(actionHistoryBack, event) {
+			reportError(uiState, "tree", navigateBack(uiData, uiState))
+			return nil
+		}
+		if uiState.keymap.Matches(actionHistoryForward, event) {
+			reportError(uiState, "tree", navigateForward(uiData, uiState))
+			return nil
+		}
+
+		// select a segment of the breadcrumb
+		if uiState.keymap.Matches(actionBreadcrumb, event) {
+			setFocusOn(uiState, uiState.breadcrumb)
+			renderBreadcrumb(uiState)
+			return nil
+		}
+
+		// toggle a bookmark
+		if uiState.keymap.Matches(actionBookmark, event) {
+			reportError(uiState, "tree", toggleBookmark(uiState, uiState.apiResourcesTreeView.GetCurrentNode()))
 			return nil
 		}
 
 		return event
 	})
-	if listenersErr != nil {
-		return listenersErr
-	}
 
 	// Handle selection changes
 	uiState.apiResourcesTreeView.SetChangedFunc(func(node *tview.TreeNode) {
 		if node == nil {
 			return
 		}
-		data, err := extractTreeData(node)
-		if err != nil {
-			listenersErr = err
-			return
+		updateBreadcrumb(uiState, node)
+		reportError(uiState, "tree", showNodeDetails(uiData, uiState, node))
+	})
+	return nil
+}
+
+// selectNode opens a group or resource in preview, expands or collapses fields (ENTER, or a double-click)
+func selectNode(uiData *UIData, uiState *UIState, node *tview.TreeNode) error {
+	if node == nil {
+		return nil
+	}
+
+	// open subview with a subtree
+	data, err := extractTreeData(node)
+	if err != nil {
+		return err
+	}
+
+	// jump from search results to the node in the full tree
+	if uiState.isInFilter && data.IsNodeType(nodeTypeResource, nodeTypeField, nodeTypeDefinition) {
+		return navigateToNode(uiData, uiState, node)
+	}
+
+	// jump from the "used by" list of a definition to the resource or the field
+	if data.IsNodeType(nodeTypeUsage) && data.gvr != nil {
+		return jumpToUsage(uiData, uiState, data)
+	}
+
+	// a recursive type is visited once more on demand
+	if data.IsNodeType(nodeTypeRecursion) {
+		return expandRecursion(uiState, node)
+	}
+
+	if data.IsNodeType(nodeTypeGroup, nodeTypeResource) {
+		// not in preview, add to view-stack
+		if !data.inPreview {
+			err := pushPreview(uiState, node)
+			if err != nil {
+				return err
+			}
+			uiState.history.Visit(nil, getOriginalNode(uiState, node))
+		} else {
+			node.SetExpanded(!node.IsExpanded())
 		}
-		uiState.apiResourcesDetailsView.SetText(data.path)
-		if data.IsNodeType(nodeTypeField, nodeTypeResource) {
-			explainPath(uiState, data, uiData)
+	} else if data.IsNodeType(nodeTypeRoot) {
+		// expand/collapse all groups
+		for _, nc := range node.GetChildren() {
+			nc.SetExpanded(!nc.IsExpanded())
 		}
-	})
-	if listenersErr != nil {
-		return listenersErr
+	} else {
+		// just expand subtree
+		node.SetExpanded(!node.IsExpanded())
 	}
 	return nil
 }
 
+func showNodeDetails(uiData *UIData, uiState *UIState, node *tview.TreeNode) error {
+	data, err := extractTreeData(node)
+	if err != nil {
+		return err
+	}
+	resetDetailsPages(uiState)
+	uiState.apiResourcesDetailsView.SetText(data.path)
+	if data.IsNodeType(nodeTypeField, nodeTypeResource, nodeTypeRecursion) {
+		explainPath(uiState, data, uiData)
+	}
+	if data.IsNodeType(nodeTypeDefinition, nodeTypeDefinitionField) {
+		explainDefinition(uiState, data)
+	}
+	if data.IsNodeType(nodeTypeError) {
+		uiState.apiResourcesDetailsView.SetText(getResourceErrorDescription(data))
+	}
+	return nil
+}
+
+// explainPath renders the details from the OpenAPI v3 schema, the plaintext explain output is a fallback,
+// when the schema can't be resolved
 func explainPath(uiState *UIState, data *TreeData, uiData *UIData) {
+	var header string
+	if data.meta.isDeprecated() {
+		header = theme.colorize(theme.deprecated, "DEPRECATED") + "\n\n"
+	}
+	access := getAccessDescription(data)
+	if access != "" {
+		access = "\n" + access
+	}
+
+	fs, err := uiState.schemaResolver.ResolveField(*data.gvr, data.path)
+	if err != nil {
+		slog.Debug("schema", slog.String("path", data.path), slog.String("resolve-failed", err.Error()))
+	} else {
+		links := &detailsLinks{}
+		showDetailsPage(uiState, &detailsPage{
+			fs:    fs,
+			text:  header + renderFieldDetails(fs, data.path, links) + access + getSchemaDetails(data, fs),
+			links: links,
+		})
+		return
+	}
+
+	header = fmt.Sprintf("%s%s\n%s", header, data.path, access)
+	footer := getSchemaDetails(data, nil)
 	if cached, ok := uiState.explainCache.Load(data.path); ok {
 		slog.Debug("explain", slog.String("cached", data.path))
-		uiState.apiResourcesDetailsView.SetText(fmt.Sprintf("%s\n\n%s", data.path, cached))
+		uiState.apiResourcesDetailsView.SetText(fmt.Sprintf("%s\n%s%s", header, cached, footer))
 	} else {
 		slog.Debug("explain", slog.String("perform", data.path))
 		explainer := NewExplainer(*data.gvr, uiData.OpenAPIClient)
 		buf := bytes.Buffer{}
 		err := explainer.Explain(&buf, data.path)
-		if err == nil {
-			uiState.apiResourcesDetailsView.SetText(fmt.Sprintf("%s\n\n%s", data.path, buf.String()))
+		if err != nil {
+			reportExplainError(uiState, *data.gvr, fmt.Errorf("explain %s: %w", data.path, err))
+		} else {
+			uiState.apiResourcesDetailsView.SetText(fmt.Sprintf("%s\n%s%s", header, buf.String(), footer))
 			uiState.explainCache.Store(data.path, buf.String())
 		}
 	}
 }
 
+// reportExplainError reports only the first failure of a group-version in the status line,
+// since explain runs on every cursor move, the rest are written to the debug log
+func reportExplainError(uiState *UIState, gvr schema.GroupVersionResource, err error) {
+	if gv := gvr.GroupVersion(); !uiState.explainFailures[gv] {
+		uiState.explainFailures[gv] = true
+		reportError(uiState, "explain", err)
+		return
+	}
+	slog.Debug("explain", slog.String("error", err.Error()))
+}
+
+// getSchemaDetails renders sections that are omitted by the plaintext explain output, the schema may be nil
+func getSchemaDetails(data *TreeData, fs *FieldSchema) string {
+	sections := []string{
+		data.meta.getCollectionDescription(),
+	}
+	if fs != nil {
+		sections = append(sections,
+			getConstraintsDescription(fs),
+			getCELValidationsDescription(fs),
+		)
+	}
+	sb := strings.Builder{}
+	for _, section := range sections {
+		if section != "" {
+			sb.WriteString("\n")
+			sb.WriteString(tview.Escape(section))
+		}
+	}
+	return sb.String()
+}
+
 func expandCollapseHJKL(uiState *UIState, expanded bool) error {
 	curNode := uiState.apiResourcesTreeView.GetCurrentNode()
 	data, err := extractTreeData(curNode)
@@ -192,7 +312,15 @@ func expandCollapseHJKL(uiState *UIState, expanded bool) error {
 	}
 
 	// expand/collapse node itself
-	if data.IsNodeType(nodeTypeField, nodeTypeGroup) {
+	if data.IsNodeType(nodeTypeField, nodeTypeGroup, nodeTypeDefinition, nodeTypeUsage) {
+		curNode.SetExpanded(expanded)
+	}
+
+	// a recursive type is visited once more, when it's expanded for the first time
+	if data.IsNodeType(nodeTypeRecursion) {
+		if expanded && len(curNode.GetChildren()) == 0 {
+			return expandRecursion(uiState, curNode)
+		}
 		curNode.SetExpanded(expanded)
 	}
 
@@ -208,38 +336,59 @@ func expandCollapseHJKL(uiState *UIState, expanded bool) error {
 
 func setupListenersForResourceDetailsView(uiState *UIState) error {
 	uiState.apiResourcesDetailsView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		if event.Key() == tcell.KeyTab {
+		if uiState.keymap.Matches(actionSwitchFocus, event) {
 			setFocusOn(uiState, uiState.apiResourcesTreeView) // Switch focus to the TreeView
 			return nil
 		}
+
+		// type references: n/N (by default) move between them, ENTER opens the definition, ESC gets back
+		if uiState.keymap.Matches(actionNextLink, event) {
+			moveDetailsLink(uiState, 1)
+			return nil
+		}
+		if uiState.keymap.Matches(actionPrevLink, event) {
+			moveDetailsLink(uiState, -1)
+			return nil
+		}
+		if uiState.keymap.Matches(actionFollowLink, event) {
+			reportError(uiState, "details", followDetailsLink(uiState))
+			return nil
+		}
+		if uiState.keymap.Matches(actionStepBack, event) && backDetailsLink(uiState) {
+			return nil
+		}
 		return event
 	})
 	return nil
 }
 
-func setupListenersForCmdInput(uiState *UIState) error {
+func setupListenersForCmdInput(uiData *UIData, uiState *UIState) error {
 	// Command was set, process it, close input cmd, set focus onto the tree
 	uiState.cmdInput.SetDoneFunc(func(key tcell.Key) {
 		// handle ENTER: search or CMD
 		if key == tcell.KeyEnter {
-			// search
-			if uiState.cmdInputIsOn && uiState.cmdInputPurpose == cmdInputPurposeSearch {
-				searchTerm := uiState.cmdInput.GetText()
-				showFilteredTree(uiState, uiState.apiResourcesTreeView, searchTerm)
-			}
-
-			// quit
-			if uiState.cmdInputIsOn && uiState.cmdInputPurpose == cmdInputPurposeCmd {
-				cmd := uiState.cmdInput.GetText()
-				if cmd == "q" {
-					uiState.app.Stop()
-				}
-			}
+			text := uiState.cmdInput.GetText()
+			purpose := uiState.cmdInputPurpose
+			wasOn := uiState.cmdInputIsOn
 
 			uiState.cmdInput.SetText("")
 			uiState.cmdInputIsOn = false
 			uiState.mainLayout.RemoveItem(uiState.cmdInput)   // Hide the input field
 			setFocusOn(uiState, uiState.apiResourcesTreeView) // Focus back to main layout
+
+			// search
+			if wasOn && purpose == cmdInputPurposeSearch {
+				recordLocation(uiState)
+				showFilteredTree(uiState, uiState.apiResourcesTreeView, text)
+			}
+
+			// commands, executed when the input is hidden, so they may show popups
+			if wasOn && purpose == cmdInputPurposeCmd {
+				clearStatus(uiState)
+				if err := uiState.commands.Run(uiData, uiState, text); err != nil {
+					setStatusError(uiState, err)
+				}
+			}
 		}
 
 		// handle ESC: hide cmd-input on ESC
@@ -252,6 +401,21 @@ func setupListenersForCmdInput(uiState *UIState) error {
 		}
 	})
 
+	// TAB completes commands and their arguments, candidates are shown in the status line
+	uiState.cmdInput.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() != tcell.KeyTab || uiState.cmdInputPurpose != cmdInputPurposeCmd {
+			return event
+		}
+		line, candidates := uiState.commands.Complete(uiState, uiState.cmdInput.GetText())
+		uiState.cmdInput.SetText(line)
+		if len(candidates) > 1 {
+			setStatusHint(uiState, strings.Join(candidates, "  "))
+		} else {
+			clearStatus(uiState)
+		}
+		return nil
+	})
+
 	return nil
 }
 
@@ -270,66 +434,105 @@ func getClosestParentThatHasChildren(uiState *UIState, node *tview.TreeNode) *tv
 func setupListenersForApp(uiState *UIState) error {
 	// Set up application key events
 	uiState.app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		// search input
-		if event.Key() == tcell.KeyRune && event.Rune() == '/' {
-			if uiState.cmdInputIsOn {
-				return nil
-			}
-			uiState.cmdInput.SetLabel("Search:")
-			uiState.cmdInputIsOn = true
-			uiState.cmdInputPurpose = cmdInputPurposeSearch
-			uiState.mainLayout.AddItem(uiState.cmdInput, 3, 1, true) // Show the input field
-			setFocusOn(uiState, uiState.cmdInput)                    // Focus on the input field
-			return nil                                               // Prevent further processing
-		}
+		return handleAppKey(uiState, event)
+	})
+	return nil
+}
 
-		// command input
-		if event.Key() == tcell.KeyRune && event.Rune() == ':' {
-			if uiState.cmdInputIsOn {
-				return nil
-			}
-			uiState.cmdInput.SetLabel("Command:")
-			uiState.cmdInputIsOn = true
-			uiState.cmdInputPurpose = cmdInputPurposeCmd
-			uiState.mainLayout.AddItem(uiState.cmdInput, 3, 1, true) // Show the input field
-			setFocusOn(uiState, uiState.cmdInput)                    // Focus on the input field
-			return nil                                               // Prevent further processing
-		}
+// handleAppKey handles global actions, keys of popups and of the input are passed through,
+// so commands and search terms may contain keys of the actions, e.g. ':goto jobs'
+func handleAppKey(uiState *UIState, event *tcell.EventKey) *tcell.EventKey {
+	// numeric prefixes and chords, that are in progress, are dropped when keys go to popups or to the input
+	if isModalShown(uiState) || uiState.cmdInputIsOn {
+		uiState.keymap.Reset()
+		return event
+	}
+	if uiState.keymap.Feed(event) {
+		return nil
+	}
 
-		// back to closest-parent
-		if event.Key() == tcell.KeyRune && event.Rune() == 'b' {
-			currentNode := uiState.apiResourcesTreeView.GetCurrentNode()
-			closestParentThatHasChildren := getClosestParentThatHasChildren(uiState, currentNode)
-			if closestParentThatHasChildren != nil {
-				uiState.apiResourcesTreeView.SetCurrentNode(closestParentThatHasChildren)
-			}
-			return nil
+	// search input
+	if uiState.keymap.Matches(actionSearch, event) {
+		uiState.cmdInput.SetLabel("Search:")
+		uiState.cmdInputIsOn = true
+		uiState.cmdInputPurpose = cmdInputPurposeSearch
+		uiState.mainLayout.AddItem(uiState.cmdInput, 3, 1, true) // Show the input field
+		setFocusOn(uiState, uiState.cmdInput)                    // Focus on the input field
+		return nil                                               // Prevent further processing
+	}
+
+	// command input
+	if uiState.keymap.Matches(actionCommand, event) {
+		uiState.cmdInput.SetLabel("Command:")
+		uiState.cmdInputIsOn = true
+		uiState.cmdInputPurpose = cmdInputPurposeCmd
+		uiState.mainLayout.AddItem(uiState.cmdInput, 3, 1, true) // Show the input field
+		setFocusOn(uiState, uiState.cmdInput)                    // Focus on the input field
+		return nil                                               // Prevent further processing
+	}
+
+	// full-screen help
+	if uiState.keymap.Matches(actionHelp, event) {
+		showHelp(uiState)
+		return nil
+	}
+
+	// layout: resize the split, switch it, zoom a pane
+	switch {
+	case uiState.keymap.Matches(actionGrowTree, event):
+		growTreePane(uiState, paneResizeStep)
+		return nil
+	case uiState.keymap.Matches(actionShrinkTree, event):
+		growTreePane(uiState, -paneResizeStep)
+		return nil
+	case uiState.keymap.Matches(actionToggleLayout, event):
+		toggleLayout(uiState)
+		return nil
+	case uiState.keymap.Matches(actionZoom, event):
+		toggleZoom(uiState)
+		return nil
+	}
+
+	// back to closest-parent
+	if uiState.keymap.Matches(actionParent, event) {
+		currentNode := uiState.apiResourcesTreeView.GetCurrentNode()
+		closestParentThatHasChildren := getClosestParentThatHasChildren(uiState, currentNode)
+		if closestParentThatHasChildren != nil {
+			uiState.apiResourcesTreeView.SetCurrentNode(closestParentThatHasChildren)
 		}
+		return nil
+	}
 
-		return event
-	})
-	return nil
+	return event
 }
 
 func setFocusOn(uiState *UIState, curFocus tview.Primitive) {
 	uiState.app.SetFocus(curFocus)
+	updateFocusBorders(uiState, curFocus)
+}
 
+// updateFocusBorders is also called by focus funcs of the views, when they are focused by a mouse click
+func updateFocusBorders(uiState *UIState, curFocus tview.Primitive) {
 	// TODO: simplify this (loops, arrays, bitmasks ?)
 	// the idea is simple: there are a bunch of views that may be focused,
 	// change the border-color for the view that is under focus right now, and reset border-color
 	// for all other views.
 	switch curFocus {
 	case uiState.apiResourcesTreeView:
-		uiState.apiResourcesTreeView.SetBorderColor(focusColor)
-		uiState.apiResourcesDetailsView.SetBorderColor(noFocusColor)
-		uiState.cmdInput.SetBorderColor(noFocusColor)
+		uiState.apiResourcesTreeView.SetBorderColor(theme.focusBorder)
+		uiState.apiResourcesDetailsView.SetBorderColor(theme.noFocusBorder)
+		uiState.cmdInput.SetBorderColor(theme.noFocusBorder)
 	case uiState.apiResourcesDetailsView:
-		uiState.apiResourcesTreeView.SetBorderColor(noFocusColor)
-		uiState.apiResourcesDetailsView.SetBorderColor(focusColor)
-		uiState.cmdInput.SetBorderColor(noFocusColor)
+		uiState.apiResourcesTreeView.SetBorderColor(theme.noFocusBorder)
+		uiState.apiResourcesDetailsView.SetBorderColor(theme.focusBorder)
+		uiState.cmdInput.SetBorderColor(theme.noFocusBorder)
 	case uiState.cmdInput:
-		uiState.apiResourcesTreeView.SetBorderColor(noFocusColor)
-		uiState.apiResourcesDetailsView.SetBorderColor(noFocusColor)
-		uiState.cmdInput.SetBorderColor(focusColor)
+		uiState.apiResourcesTreeView.SetBorderColor(theme.noFocusBorder)
+		uiState.apiResourcesDetailsView.SetBorderColor(theme.noFocusBorder)
+		uiState.cmdInput.SetBorderColor(theme.focusBorder)
+	case uiState.breadcrumb:
+		uiState.apiResourcesTreeView.SetBorderColor(theme.noFocusBorder)
+		uiState.apiResourcesDetailsView.SetBorderColor(theme.noFocusBorder)
+		uiState.cmdInput.SetBorderColor(theme.noFocusBorder)
 	}
 }
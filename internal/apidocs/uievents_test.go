@@ -0,0 +1,37 @@
+package apidocs
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func TestHandleAppKey_TypingInInput(t *testing.T) {
+	keymap, err := NewKeymap(nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	pages := tview.NewPages()
+	pages.AddPage(pageMain, tview.NewBox(), true, true)
+	uiState := &UIState{
+		pages:        pages,
+		keymap:       keymap,
+		cmdInput:     tview.NewInputField(),
+		cmdInputIsOn: true,
+	}
+
+	// 'b', '/' and ':' are global actions, they're typed into the input, while it's open
+	command := "goto jobs /tmp/x:y b"
+	handler := uiState.cmdInput.InputHandler()
+	for _, r := range command {
+		event := handleAppKey(uiState, tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+		if event == nil {
+			t.Fatalf("The key %q was swallowed", r)
+		}
+		handler(event, func(tview.Primitive) {})
+	}
+	if text := uiState.cmdInput.GetText(); text != command {
+		t.Fatalf("Expected %q, got %q", command, text)
+	}
+}
@@ -0,0 +1,30 @@
+package apidocs
+
+import "github.com/rivo/tview"
+
+// setSubtreeExpanded expands a subtree down to a depth below the node (zero means unlimited), or collapses it.
+// Resources, that are not opened in preview, are skipped, they're managed by ENTER.
+func setSubtreeExpanded(node *tview.TreeNode, expanded bool, depth int) {
+	var walk func(n *tview.TreeNode, level int)
+	walk = func(n *tview.TreeNode, level int) {
+		data, err := extractTreeData(n)
+		if err != nil {
+			return
+		}
+		if data.IsNodeType(nodeTypeResource) && !data.inPreview {
+			return
+		}
+		if !expanded {
+			n.SetExpanded(false)
+		} else {
+			n.SetExpanded(depth == 0 || level < depth)
+			if !n.IsExpanded() {
+				return
+			}
+		}
+		for _, child := range n.GetChildren() {
+			walk(child, level+1)
+		}
+	}
+	walk(node, 0)
+}
@@ -0,0 +1,38 @@
+package apidocs
+
+import (
+	"testing"
+
+	"github.com/rivo/tview"
+)
+
+func newTestFieldNode(name string, children ...*tview.TreeNode) *tview.TreeNode {
+	node := tview.NewTreeNode(name).SetReference(&TreeData{nodeType: nodeTypeField}).SetExpanded(false)
+	for _, c := range children {
+		node.AddChild(c)
+	}
+	return node
+}
+
+func TestSetSubtreeExpanded(t *testing.T) {
+	container := newTestFieldNode("containers", newTestFieldNode("ports", newTestFieldNode("name")))
+	spec := newTestFieldNode("spec", container)
+
+	setSubtreeExpanded(spec, true, 2)
+	if !spec.IsExpanded() || !container.IsExpanded() {
+		t.Fatal("Expected two levels to be expanded")
+	}
+	if ports := container.GetChildren()[0]; ports.IsExpanded() {
+		t.Fatal("Expected the third level to be collapsed")
+	}
+
+	setSubtreeExpanded(spec, true, 0)
+	if ports := container.GetChildren()[0]; !ports.IsExpanded() {
+		t.Fatal("Expected the whole subtree to be expanded")
+	}
+
+	setSubtreeExpanded(spec, false, 0)
+	if spec.IsExpanded() || container.IsExpanded() {
+		t.Fatal("Expected the whole subtree to be collapsed")
+	}
+}
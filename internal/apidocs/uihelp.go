@@ -0,0 +1,139 @@
+package apidocs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const pageHelp = "help"
+
+// helpMenuEntry is a key (or keys) with a short description for the help menu
+type helpMenuEntry struct {
+	keys        string
+	description string
+	// details is a longer description for the help page
+	details string
+}
+
+// getKeyHelpEntries lists the core keys of the active keymap, with the built-in ones,
+// they're shown in the help menu, that has room for two rows on narrow terminals as well
+func getKeyHelpEntries(keymap *Keymap) []helpMenuEntry {
+	return []helpMenuEntry{
+		{keymap.KeysFor(actionSearch), "Search", "Open search mode, the tree is filtered by the entered text"},
+		{"ctrl-c", "Quit", "Quit application"},
+		{keymap.KeysFor(actionCommand), "Command", "Execute a command (see below), TAB completes commands and their arguments"},
+		{keymap.KeysFor(actionSwitchFocus), "Focus", "Switch focus between tree/details (the details view is scrollable)"},
+		{"enter", "Select", "Select a group/resource, ENTER on a search result jumps to it in the full tree"},
+		{keymap.KeysFor(actionStepBack), "Step back", "Step back in navigation, or leave the search results"},
+		{"j/k", "Navigate", "Move the selection up/down (or the arrow keys)"},
+		{keymap.KeysFor(actionHelp), "Help", "Show this help"},
+	}
+}
+
+// getExtraKeyHelpEntries lists the keys, that are shown on the help page only
+func getExtraKeyHelpEntries(keymap *Keymap) []helpMenuEntry {
+	return []helpMenuEntry{
+		{keymap.KeysFor(actionParent), "Parent", "Step back to closest root"},
+		{keymap.KeysFor(actionCollapse), "Collapse", "Collapse the selected field"},
+		{keymap.KeysFor(actionExpand), "Expand", "Expand the selected field"},
+		{keymap.KeysFor(actionCopy), "Copy", "Copy path, kubectl explain argument, JSONPath or details (OSC 52)"},
+		{keymap.KeysFor(actionQuery), "Query", "Query expressions: JSONPath, custom-columns, field-selector, jq, yq, CEL"},
+		{keymap.KeysFor(actionBookmark), "Mark", "Toggle a bookmark on the selected field (see :marks)"},
+		{keymap.KeysFor(actionBreadcrumb), "Breadcrumb", "Select a breadcrumb segment (h/l, ENTER to jump back up)"},
+		{keymap.KeysFor(actionHistoryBack), "Back", "History: back across jumps (search, commands, bookmarks)"},
+		{keymap.KeysFor(actionHistoryForward), "Forward", "History: forward across jumps"},
+		{keymap.KeysFor(actionCollapseAll), "Collapse all", "Collapse the subtree of the selected node"},
+		{keymap.KeysFor(actionExpandAll), "Expand all", "Expand the subtree of the selected node, a count limits the depth, e.g. 3L"},
+		{keymap.KeysFor(actionGrowTree), "Grow", "Grow the tree pane"},
+		{keymap.KeysFor(actionShrinkTree), "Shrink", "Shrink the tree pane"},
+		{keymap.KeysFor(actionToggleLayout), "Layout", "Switch between the horizontal and the vertical split"},
+		{keymap.KeysFor(actionZoom), "Zoom", "Show the focused pane (tree or details) in full size, or restore the split"},
+		{keymap.KeysFor(actionNextLink) + "/" + keymap.KeysFor(actionPrevLink), "Links", "Highlight the next/previous type reference in the details"},
+		{keymap.KeysFor(actionFollowLink), "Follow", "Open the definition of the highlighted type in the details, ESC gets back"},
+		{keymap.KeysFor(actionClose), "Close", "Close the help page or the message log"},
+		{keymap.KeysFor(actionRemove), "Remove", "Remove the selected bookmark in the bookmarks list"},
+	}
+}
+
+// getCommandHelpEntries lists the commands of the registry for the help page
+func getCommandHelpEntries(commands *CommandRegistry) []helpMenuEntry {
+	entries := make([]helpMenuEntry, 0, len(commands.commands))
+	for _, c := range commands.commands {
+		usage := ":" + c.name
+		if c.args != "" {
+			usage += " " + c.args
+		}
+		entries = append(entries, helpMenuEntry{keys: usage, description: c.description})
+	}
+	return entries
+}
+
+// getHelpMenuContent lays out the keys of the active keymap in two rows, column by column
+func getHelpMenuContent(keymap *Keymap) string {
+	entries := getKeyHelpEntries(keymap)
+
+	rows := [2]strings.Builder{}
+	for i := 0; i < len(entries); i += 2 {
+		column := entries[i:min(i+2, len(entries))]
+		width := 0
+		for _, e := range column {
+			width = max(width, len(e.keys)+len(e.description)+3)
+		}
+		for row, e := range column {
+			padding := strings.Repeat(" ", width-len(e.keys)-len(e.description)-3)
+			rows[row].WriteString(fmt.Sprintf("%s %s%s | ", theme.colorize(theme.accent, "<"+tview.Escape(e.keys)+">"), e.description, padding))
+		}
+	}
+	return strings.TrimRight(rows[0].String(), " ") + "\n" + strings.TrimRight(rows[1].String(), " ")
+}
+
+// getHelpPageContent lists all keys and commands with their descriptions
+func getHelpPageContent(keymap *Keymap, commands *CommandRegistry) string {
+	sb := strings.Builder{}
+	writeSection := func(title string, entries []helpMenuEntry) {
+		width := 0
+		for _, e := range entries {
+			width = max(width, len(e.keys))
+		}
+		sb.WriteString(theme.colorize(theme.root, title) + "\n\n")
+		for _, e := range entries {
+			description := e.description
+			if e.details != "" {
+				description = e.details
+			}
+			padding := strings.Repeat(" ", width-len(e.keys))
+			sb.WriteString(fmt.Sprintf("  %s%s  %s\n", theme.colorize(theme.accent, tview.Escape(e.keys)), padding, tview.Escape(description)))
+		}
+		sb.WriteString("\n")
+	}
+
+	writeSection("KEYS", append(getKeyHelpEntries(keymap), getExtraKeyHelpEntries(keymap)...))
+	writeSection("COMMANDS", getCommandHelpEntries(commands))
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// showHelp opens a scrollable page with all keys and commands, step back and close keys (ESC/q),
+// or the help key close it
+func showHelp(uiState *UIState) {
+	view := tview.NewTextView()
+	view.SetDynamicColors(true)
+	view.SetScrollable(true)
+	view.SetWrap(false)
+	view.SetBorder(true)
+	view.SetTitle(fmt.Sprintf("Help (%s: close)", uiState.keymap.KeysFor(actionClose)))
+	view.SetText(getHelpPageContent(uiState.keymap, uiState.commands))
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if uiState.keymap.Matches(actionStepBack, event) ||
+			uiState.keymap.Matches(actionClose, event) ||
+			uiState.keymap.Matches(actionHelp, event) {
+			hideModal(uiState, pageHelp)
+			return nil
+		}
+		return event
+	})
+
+	showModal(uiState, pageHelp, view, 100, 40)
+}
@@ -0,0 +1,30 @@
+package apidocs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rivo/tview"
+)
+
+func TestGetHelpMenuContent_Width(t *testing.T) {
+	keymap, err := NewKeymap(nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	lines := strings.Split(getHelpMenuContent(keymap), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected two rows, got %q", lines)
+	}
+	// the help menu fits an 80 columns terminal with its border
+	for _, line := range lines {
+		if width := tview.TaggedStringWidth(line); width > 78 {
+			t.Fatalf("The help menu row is %d columns wide: %q", width, line)
+		}
+	}
+	for _, description := range []string{"Quit", "Focus", "Step back", "Help"} {
+		if !strings.Contains(lines[0]+lines[1], description) {
+			t.Fatalf("Expected %q in the help menu", description)
+		}
+	}
+}
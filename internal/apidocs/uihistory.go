@@ -0,0 +1,52 @@
+package apidocs
+
+import (
+	"fmt"
+
+	"github.com/rivo/tview"
+)
+
+const pageHistory = "history"
+
+// showHistory lists visited nodes, ENTER jumps to the chosen one
+func showHistory(uiData *UIData, uiState *UIState) {
+	list := tview.NewList()
+	list.SetUseStyleTags(false, false)
+	list.ShowSecondaryText(false)
+	list.SetBorder(true)
+	list.SetTitle("History (ENTER: jump, ESC: close)")
+
+	entries := uiState.history.Entries()
+	if len(entries) == 0 {
+		list.AddItem("(no history yet)", "", 0, nil)
+	}
+	for i, node := range entries {
+		marker := "  "
+		if i == uiState.history.Pos() {
+			marker = "▶ "
+		}
+		list.AddItem(marker+getNodeLocation(node), "", 0, func() {
+			hideModal(uiState, pageHistory)
+			if n := uiState.history.MoveTo(i); n != nil {
+				reportError(uiState, "history", jumpToNode(uiData, uiState, n))
+			}
+		})
+	}
+	if pos := uiState.history.Pos(); pos >= 0 {
+		list.SetCurrentItem(pos)
+	}
+	list.SetDoneFunc(func() {
+		hideModal(uiState, pageHistory)
+	})
+
+	showModal(uiState, pageHistory, list, 100, min(len(entries), 20)+3)
+}
+
+// getNodeLocation describes a node for lists, e.g. 'apps/v1  deployments.spec.replicas'
+func getNodeLocation(node *tview.TreeNode) string {
+	data, err := extractTreeData(node)
+	if err != nil || data.gvr == nil {
+		return getNodeSearchText(node)
+	}
+	return fmt.Sprintf("%s  %s", data.gvr.GroupVersion().String(), data.path)
+}
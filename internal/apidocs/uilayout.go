@@ -0,0 +1,131 @@
+package apidocs
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const (
+	layoutAuto       = "auto"
+	layoutHorizontal = "horizontal"
+	layoutVertical   = "vertical"
+
+	// narrowScreenWidth is a width of the terminal, below which the auto layout stacks the panes
+	narrowScreenWidth = 120
+	// minPaneSize keeps both panes usable, when the split is resized to an edge
+	minPaneSize = 5
+	// paneResizeStep is a number of cells the tree pane grows/shrinks by a key
+	paneResizeStep = 4
+)
+
+// getLayoutMode validates a layout from the config file, an empty one means auto
+func getLayoutMode(name string) (string, error) {
+	switch name {
+	case "":
+		return layoutAuto, nil
+	case layoutAuto, layoutHorizontal, layoutVertical:
+		return name, nil
+	}
+	return "", fmt.Errorf("unknown layout: %q (available: auto, horizontal, vertical)", name)
+}
+
+// isVerticalLayout reports whether the tree and the details are stacked
+func isVerticalLayout(uiState *UIState) bool {
+	return uiState.layoutMode == layoutVertical ||
+		(uiState.layoutMode == layoutAuto && uiState.screenWidth > 0 && uiState.screenWidth < narrowScreenWidth)
+}
+
+// applyLayout sets the direction of the split, and sizes of the panes: a zoomed pane takes all the space,
+// a resized tree pane has a fixed size, otherwise the panes take a half each
+func applyLayout(uiState *UIState) {
+	layout := uiState.apiResourcesViewsLayout
+	tree := uiState.apiResourcesTreeView
+	details := uiState.apiResourcesDetailsView
+
+	if isVerticalLayout(uiState) {
+		layout.SetDirection(tview.FlexRow)
+	} else {
+		layout.SetDirection(tview.FlexColumn)
+	}
+
+	switch {
+	case uiState.zoomedPane == tree:
+		layout.ResizeItem(tree, 0, 1)
+		layout.ResizeItem(details, 0, 0)
+	case uiState.zoomedPane == details:
+		layout.ResizeItem(tree, 0, 0)
+		layout.ResizeItem(details, 0, 1)
+	case uiState.treePaneSize > 0:
+		layout.ResizeItem(tree, uiState.treePaneSize, 0)
+		layout.ResizeItem(details, 0, 1)
+	default:
+		layout.ResizeItem(tree, 0, 1)
+		layout.ResizeItem(details, 0, 1)
+	}
+}
+
+// getTreePaneSize returns the current size of the tree pane along the split, and the size of the whole layout
+func getTreePaneSize(uiState *UIState) (int, int) {
+	_, _, treeWidth, treeHeight := uiState.apiResourcesTreeView.GetRect()
+	_, _, layoutWidth, layoutHeight := uiState.apiResourcesViewsLayout.GetRect()
+	if isVerticalLayout(uiState) {
+		return treeHeight, layoutHeight
+	}
+	return treeWidth, layoutWidth
+}
+
+// resizeTreePane sets a fixed size of the tree pane, the details pane takes the rest
+func resizeTreePane(uiState *UIState, size int) {
+	_, layoutSize := getTreePaneSize(uiState)
+	uiState.treePaneSize = max(minPaneSize, min(size, layoutSize-minPaneSize))
+	uiState.zoomedPane = nil
+	applyLayout(uiState)
+}
+
+// growTreePane grows (or shrinks by a negative delta) the tree pane
+func growTreePane(uiState *UIState, delta int) {
+	size, _ := getTreePaneSize(uiState)
+	resizeTreePane(uiState, size+delta)
+}
+
+// toggleLayout switches between the horizontal and the vertical split, the size of the tree pane is reset
+func toggleLayout(uiState *UIState) {
+	if isVerticalLayout(uiState) {
+		uiState.layoutMode = layoutHorizontal
+	} else {
+		uiState.layoutMode = layoutVertical
+	}
+	uiState.treePaneSize = 0
+	applyLayout(uiState)
+}
+
+// toggleZoom shows the focused pane (the tree, or the details) in the full size of the layout, or restores the split
+func toggleZoom(uiState *UIState) {
+	if uiState.zoomedPane != nil {
+		uiState.zoomedPane = nil
+	} else if uiState.app.GetFocus() == uiState.apiResourcesDetailsView {
+		uiState.zoomedPane = uiState.apiResourcesDetailsView
+	} else {
+		uiState.zoomedPane = uiState.apiResourcesTreeView
+		setFocusOn(uiState, uiState.apiResourcesTreeView)
+	}
+	applyLayout(uiState)
+}
+
+// setupLayout follows the width of the terminal, so the auto layout is switched on resize
+func setupLayout(uiState *UIState) {
+	uiState.app.SetBeforeDrawFunc(func(screen tcell.Screen) bool {
+		width, _ := screen.Size()
+		if width != uiState.screenWidth {
+			wasVertical := isVerticalLayout(uiState)
+			uiState.screenWidth = width
+			if wasVertical != isVerticalLayout(uiState) {
+				uiState.treePaneSize = 0
+			}
+			applyLayout(uiState)
+		}
+		return false
+	})
+}
@@ -0,0 +1,32 @@
+package apidocs
+
+import "github.com/rivo/tview"
+
+const (
+	pageMain = "main"
+	pageCopy = "copy"
+)
+
+// showModal displays a primitive centered over the main layout
+func showModal(uiState *UIState, name string, p tview.Primitive, width, height int) {
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(p, height, 1, true).
+			AddItem(nil, 0, 1, false), width, 1, true).
+		AddItem(nil, 0, 1, false)
+	uiState.pages.AddPage(name, modal, true, true)
+	uiState.app.SetFocus(p)
+}
+
+func hideModal(uiState *UIState, name string) {
+	uiState.pages.RemovePage(name)
+	setFocusOn(uiState, uiState.apiResourcesTreeView)
+}
+
+// isModalShown reports whether a modal page covers the main layout, global keys are ignored then
+func isModalShown(uiState *UIState) bool {
+	name, _ := uiState.pages.GetFrontPage()
+	return name != pageMain
+}
@@ -0,0 +1,60 @@
+package apidocs
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// setupMouse handles mouse events, that are not handled by the views themselves:
+// a double-click on a node works as ENTER, a click on a view moves the focus (and its border color),
+// a drag of the border between the tree and the details resizes the split
+func setupMouse(uiData *UIData, uiState *UIState) {
+	uiState.app.EnableMouse(true)
+
+	uiState.apiResourcesTreeView.SetMouseCapture(func(action tview.MouseAction, event *tcell.EventMouse) (tview.MouseAction, *tcell.EventMouse) {
+		if action == tview.MouseLeftDoubleClick {
+			// the node under the cursor is already selected by the first click
+			reportError(uiState, "mouse", selectNode(uiData, uiState, uiState.apiResourcesTreeView.GetCurrentNode()))
+			return action, nil
+		}
+		return action, event
+	})
+
+	for _, p := range []interface {
+		tview.Primitive
+		SetFocusFunc(func()) *tview.Box
+	}{uiState.apiResourcesTreeView, uiState.apiResourcesDetailsView, uiState.cmdInput, uiState.breadcrumb} {
+		p.SetFocusFunc(func() {
+			updateFocusBorders(uiState, p)
+		})
+	}
+
+	uiState.apiResourcesViewsLayout.SetMouseCapture(func(action tview.MouseAction, event *tcell.EventMouse) (tview.MouseAction, *tcell.EventMouse) {
+		// positions along the split: columns of the horizontal layout, rows of the vertical one
+		pos, _ := event.Position()
+		treePos, _, treeSize, _ := uiState.apiResourcesTreeView.GetRect()
+		if isVerticalLayout(uiState) {
+			_, pos = event.Position()
+			_, treePos, _, treeSize = uiState.apiResourcesTreeView.GetRect()
+		}
+		switch action {
+		case tview.MouseLeftDown:
+			// the border of the tree, or the adjacent border of the details
+			if border := treePos + treeSize; uiState.zoomedPane == nil && (pos == border-1 || pos == border) {
+				uiState.isResizing = true
+				return action, nil
+			}
+		case tview.MouseMove:
+			if uiState.isResizing {
+				resizeTreePane(uiState, pos-treePos+1)
+				return action, nil
+			}
+		case tview.MouseLeftUp:
+			if uiState.isResizing {
+				uiState.isResizing = false
+				return action, nil
+			}
+		}
+		return action, event
+	})
+}
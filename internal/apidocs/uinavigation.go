@@ -0,0 +1,139 @@
+package apidocs
+
+import (
+	"github.com/rivo/tview"
+)
+
+// pushPreview opens a group or resource node as the root of the tree view
+func pushPreview(uiState *UIState, node *tview.TreeNode) error {
+	err := setInPreview(node, true)
+	if err != nil {
+		return err
+	}
+	uiState.navigationStack = append(uiState.navigationStack, node)
+	uiState.apiResourcesTreeView.SetRoot(node).SetCurrentNode(node)
+	node.SetExpanded(true)
+	return nil
+}
+
+// popPreview steps back to the previous root of the tree view, returns the node that was the root
+func popPreview(uiState *UIState) (*tview.TreeNode, error) {
+	// a node, that was used for preview, we need to clear the flag
+	cur := uiState.navigationStack[len(uiState.navigationStack)-1]
+	err := setInPreview(cur, false)
+	if err != nil {
+		return nil, err
+	}
+	data, err := extractTreeData(cur)
+	if err != nil {
+		return nil, err
+	}
+	// don't need to expand the resource, we need just its name
+	if data.IsNodeType(nodeTypeResource) {
+		cur.SetExpanded(false)
+	}
+	// always expand groups
+	if data.IsNodeType(nodeTypeGroup) {
+		cur.SetExpanded(true)
+	}
+
+	uiState.navigationStack = uiState.navigationStack[:len(uiState.navigationStack)-1]
+	prevNode := uiState.navigationStack[len(uiState.navigationStack)-1]
+	uiState.apiResourcesTreeView.SetRoot(prevNode)
+	return cur, nil
+}
+
+// jumpToNode opens a node of the full tree in the same way, as it was reached by ENTER on its group and resource,
+// all ancestors are expanded
+func jumpToNode(uiData *UIData, uiState *UIState, node *tview.TreeNode) error {
+	// drop filtered tree and the current navigation
+	uiState.isInFilter = false
+	for len(uiState.navigationStack) > 1 {
+		if _, err := popPreview(uiState); err != nil {
+			return err
+		}
+	}
+	uiState.apiResourcesTreeView.SetRoot(uiState.apiResourcesRootNode)
+
+	// collect ancestors from the root down to the node
+	var chain []*tview.TreeNode
+	for n := node; n != nil; n = uiState.treeLinks.ParentMap[n] {
+		chain = append([]*tview.TreeNode{n}, chain...)
+	}
+	for _, n := range chain {
+		data, err := extractTreeData(n)
+		if err != nil {
+			return err
+		}
+		if data.IsNodeType(nodeTypeGroup, nodeTypeResource) {
+			if err := pushPreview(uiState, n); err != nil {
+				return err
+			}
+		} else if n != node {
+			n.SetExpanded(true)
+		}
+	}
+
+	uiState.apiResourcesTreeView.SetCurrentNode(node)
+	updateBreadcrumb(uiState, node)
+	return showNodeDetails(uiData, uiState, node)
+}
+
+// getOriginalNode returns a node of the full tree for a node, that may be a clone in a filtered tree
+func getOriginalNode(uiState *UIState, node *tview.TreeNode) *tview.TreeNode {
+	if node == nil || node == uiState.apiResourcesRootNode {
+		return node
+	}
+	if _, ok := uiState.treeLinks.ParentMap[node]; ok {
+		return node
+	}
+	data, err := extractTreeData(node)
+	if err != nil {
+		return nil
+	}
+	if data.IsNodeType(nodeTypeResource, nodeTypeField) && data.gvr != nil {
+		return uiState.treeLinks.FindNode(*data.gvr, data.path)
+	}
+	if data.IsNodeType(nodeTypeDefinition) {
+		return uiState.treeLinks.DefinitionMap[data.definition]
+	}
+	if data.IsNodeType(nodeTypeRoot) {
+		return uiState.apiResourcesRootNode
+	}
+	for _, groupNode := range uiState.apiResourcesRootNode.GetChildren() {
+		if groupNode.GetText() == node.GetText() {
+			return groupNode
+		}
+	}
+	return nil
+}
+
+// navigateToNode jumps to a node, and records the jump in the history
+func navigateToNode(uiData *UIData, uiState *UIState, node *tview.TreeNode) error {
+	node = getOriginalNode(uiState, node)
+	if node == nil {
+		return nil
+	}
+	from := getOriginalNode(uiState, uiState.apiResourcesTreeView.GetCurrentNode())
+	uiState.history.Visit(from, node)
+	return jumpToNode(uiData, uiState, node)
+}
+
+// recordLocation adds the current node to the history, used before the tree is replaced by a filtered one
+func recordLocation(uiState *UIState) {
+	uiState.history.Visit(getOriginalNode(uiState, uiState.apiResourcesTreeView.GetCurrentNode()), nil)
+}
+
+func navigateBack(uiData *UIData, uiState *UIState) error {
+	if node := uiState.history.Back(); node != nil {
+		return jumpToNode(uiData, uiState, node)
+	}
+	return nil
+}
+
+func navigateForward(uiData *UIData, uiState *UIState) error {
+	if node := uiState.history.Forward(); node != nil {
+		return jumpToNode(uiData, uiState, node)
+	}
+	return nil
+}
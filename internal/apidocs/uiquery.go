@@ -0,0 +1,76 @@
+package apidocs
+
+import (
+	"fmt"
+
+	"github.com/rivo/tview"
+)
+
+const pageQuery = "query"
+
+// queryExpression is an item of the query menu, an empty value means the dialect is not supported for the field
+type queryExpression struct {
+	title    string
+	value    string
+	shortcut rune
+}
+
+// showQueryMenu renders ready-to-use expressions for the selected field, the chosen one is copied to the clipboard
+func showQueryMenu(uiState *UIState, data *TreeData) {
+	list := tview.NewList()
+	list.SetUseStyleTags(false, false)
+	list.SetBorder(true)
+	list.SetTitle(fmt.Sprintf("Query expressions: %s", data.path))
+	for _, expr := range getQueryExpressions(data.gvr.Resource, getFieldPathSegments(uiState.treeLinks, data)) {
+		if expr.value == "" {
+			list.AddItem(expr.title, "(not supported for this field)", expr.shortcut, nil)
+			continue
+		}
+		value := expr.value
+		list.AddItem(expr.title, value, expr.shortcut, func() {
+			copyToClipboard(uiState, value)
+			hideModal(uiState, pageQuery)
+		})
+	}
+	list.SetDoneFunc(func() {
+		hideModal(uiState, pageQuery)
+	})
+
+	showModal(uiState, pageQuery, list, 100, list.GetItemCount()*2+2)
+}
+
+// getQueryExpressions renders expressions for a field of a resource, or for the resource itself (no segments),
+// custom-columns are omitted for a resource, since there's no field to show in a column
+func getQueryExpressions(resource string, segments []fieldPathSegment) []queryExpression {
+	jqPath := getJQPath(segments)
+	exprs := []queryExpression{{
+		title:    "kubectl JSONPath",
+		value:    fmt.Sprintf("kubectl get %s -o jsonpath='{.items[*]%s}'", resource, jsonPathBody(segments)),
+		shortcut: 'j',
+	}}
+	if len(segments) > 0 {
+		exprs = append(exprs, queryExpression{
+			title:    "kubectl custom-columns",
+			value:    fmt.Sprintf("kubectl get %s -o custom-columns='NAME:.metadata.name,%s'", resource, getCustomColumn(segments)),
+			shortcut: 'c',
+		})
+	}
+	selector := queryExpression{title: "kubectl field-selector", shortcut: 'f'}
+	if s, ok := getFieldSelector(resource, segments); ok {
+		selector.value = fmt.Sprintf("kubectl get %s --field-selector='%s'", resource, s)
+	}
+	return append(exprs,
+		selector,
+		queryExpression{
+			title:    "jq",
+			value:    fmt.Sprintf("kubectl get %s -o json | jq '.items[] | %s'", resource, jqPath),
+			shortcut: 'q',
+		},
+		queryExpression{
+			title:    "yq",
+			value:    fmt.Sprintf("kubectl get %s -o yaml | yq '.items[] | %s'", resource, jqPath),
+			shortcut: 'y',
+		},
+		queryExpression{title: "CEL (ValidatingAdmissionPolicy)", value: getCELExpression(segments), shortcut: 'v'},
+	)
+}
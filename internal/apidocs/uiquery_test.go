@@ -0,0 +1,52 @@
+package apidocs
+
+import (
+	"strings"
+	"testing"
+)
+
+func findQueryExpression(exprs []queryExpression, title string) (queryExpression, bool) {
+	for _, expr := range exprs {
+		if expr.title == title {
+			return expr, true
+		}
+	}
+	return queryExpression{}, false
+}
+
+func TestGetQueryExpressions_Field(t *testing.T) {
+	exprs := getQueryExpressions("deployments", testContainerImageSegments)
+	expr, ok := findQueryExpression(exprs, "kubectl custom-columns")
+	if !ok || expr.value != "kubectl get deployments -o custom-columns='NAME:.metadata.name,IMAGE:.spec.template.spec.containers[*].image'" {
+		t.Fatalf("Unexpected custom-columns: %+v", expr)
+	}
+	if expr, _ := findQueryExpression(exprs, "kubectl field-selector"); expr.value != "" {
+		t.Fatalf("Expected the field-selector to be unsupported, got %q", expr.value)
+	}
+}
+
+func TestGetQueryExpressions_Resource(t *testing.T) {
+	exprs := getQueryExpressions("deployments", nil)
+	if expr, ok := findQueryExpression(exprs, "kubectl custom-columns"); ok {
+		t.Fatalf("Expected no custom-columns for a resource, got %q", expr.value)
+	}
+	for _, expr := range exprs {
+		if strings.HasSuffix(expr.value, ",'") {
+			t.Fatalf("Unexpected expression for a resource: %q", expr.value)
+		}
+	}
+	if expr, _ := findQueryExpression(exprs, "kubectl JSONPath"); expr.value != "kubectl get deployments -o jsonpath='{.items[*]}'" {
+		t.Fatalf("Unexpected JSONPath: %q", expr.value)
+	}
+	if expr, _ := findQueryExpression(exprs, "jq"); expr.value != "kubectl get deployments -o json | jq '.items[] | .'" {
+		t.Fatalf("Unexpected jq: %q", expr.value)
+	}
+}
+
+func TestGetQueryExpressions_EventsSelector(t *testing.T) {
+	segments := []fieldPathSegment{{name: "involvedObject"}, {name: "apiVersion"}}
+	expr, _ := findQueryExpression(getQueryExpressions("events", segments), "kubectl field-selector")
+	if expr.value != "kubectl get events --field-selector='involvedObject.apiVersion='" {
+		t.Fatalf("Unexpected field-selector: %q", expr.value)
+	}
+}
@@ -0,0 +1,53 @@
+package apidocs
+
+import (
+	"github.com/rivo/tview"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// recursionSign marks a field, where a recursive type recurs, e.g. '↻ JSONSchemaProps'
+const recursionSign = "↻"
+
+// newRecursionNode creates a node of a recursion point, its fields are added by expandRecursion
+func newRecursionNode(gvr *schema.GroupVersionResource, path, definition string) *tview.TreeNode {
+	data := &TreeData{
+		nodeType:   nodeTypeRecursion,
+		label:      recursionSign + " " + getShortRefName(definition),
+		path:       path,
+		gvr:        gvr,
+		definition: definition,
+	}
+	return tview.NewTreeNode(renderNodeText(data)).SetReference(data).SetExpanded(false)
+}
+
+// expandRecursion adds fields of the recursive type one more level below the recursion point,
+// it's done once, the node is just expanded or collapsed afterward
+func expandRecursion(uiState *UIState, node *tview.TreeNode) error {
+	data, err := extractTreeData(node)
+	if err != nil {
+		return err
+	}
+	if len(node.GetChildren()) > 0 {
+		node.SetExpanded(!node.IsExpanded())
+		return nil
+	}
+
+	paths, fieldMetas, err := getRecursionPaths(uiState.references, *data.gvr, data.definition, data.path)
+	if err != nil {
+		return err
+	}
+	rootFieldsNode := &ResourceFieldsNode{Name: "root"}
+	for _, fieldPath := range paths {
+		rootFieldsNode.AddPath(fieldPath)
+	}
+	fieldsNode := rootFieldsNode.Find(data.path)
+	if fieldsNode == nil || len(fieldsNode.Children) == 0 {
+		return nil
+	}
+
+	populateNodeWithResourceFields(node, fieldsNode.Children, data.gvr, fieldMetas)
+	uiState.treeLinks.FillLinks(node)
+	resetNodeColors(node)
+	markBookmarkedNodes(uiState)
+	return nil
+}
@@ -1,12 +1,30 @@
 package apidocs
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/rivo/tview"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
-func buildFilteredTree(node *tview.TreeNode, searchTerm string) *tview.TreeNode {
+// getNodePlainText returns a node text without decorations, it's the label of a decorated node
+func getNodePlainText(node *tview.TreeNode) string {
+	if data, err := extractTreeData(node); err == nil && data.label != "" {
+		return data.label
+	}
+	return strings.TrimSpace(node.GetText())
+}
+
+// getNodeSearchText returns a node text without decorations, so they do not affect the search
+func getNodeSearchText(node *tview.TreeNode) string {
+	return strings.ToLower(getNodePlainText(node))
+}
+
+// nodeMatcher reports whether a node should be kept in a filtered tree
+type nodeMatcher func(node *tview.TreeNode, data *TreeData) bool
+
+func buildFilteredTree(node *tview.TreeNode, match nodeMatcher) *tview.TreeNode {
 	if node == nil {
 		return nil
 	}
@@ -16,16 +34,12 @@ func buildFilteredTree(node *tview.TreeNode, searchTerm string) *tview.TreeNode
 		return nil
 	}
 
-	var matched bool
-	shouldHighlight := data.IsNodeType(nodeTypeResource, nodeTypeField)
-	if strings.Contains(strings.ToLower(node.GetText()), searchTerm) && shouldHighlight {
-		matched = true
-	}
+	matched := match(node, data)
 
 	// Recursively process children
 	var matchingChildren []*tview.TreeNode
 	for _, child := range node.GetChildren() {
-		filteredChild := buildFilteredTree(child, searchTerm)
+		filteredChild := buildFilteredTree(child, match)
 		if filteredChild != nil {
 			matchingChildren = append(matchingChildren, filteredChild)
 			matched = true // parent will be included if child matched
@@ -58,7 +72,16 @@ func showFilteredTree(uiState *UIState, treeView *tview.TreeView, searchTerm str
 		return
 	}
 
-	filteredRoot := buildFilteredTree(uiState.apiResourcesRootNode, strings.ToLower(searchTerm))
+	searchTerm = strings.ToLower(searchTerm)
+	showMatchingTree(uiState, treeView, func(node *tview.TreeNode, data *TreeData) bool {
+		return data.IsNodeType(nodeTypeResource, nodeTypeField, nodeTypeDefinition) &&
+			strings.Contains(getNodeSearchText(node), searchTerm)
+	})
+}
+
+// showMatchingTree replaces the tree with a filtered one, that contains matched nodes and their parents
+func showMatchingTree(uiState *UIState, treeView *tview.TreeView, match nodeMatcher) {
+	filteredRoot := buildFilteredTree(uiState.apiResourcesRootNode, match)
 	if filteredRoot == nil {
 		// Nothing matched -> empty root
 		filteredRoot = tview.NewTreeNode("(no matches)")
@@ -68,3 +91,90 @@ func showFilteredTree(uiState *UIState, treeView *tview.TreeView, searchTerm str
 	uiState.isInFilter = true
 	treeView.SetRoot(filteredRoot).SetCurrentNode(filteredRoot)
 }
+
+// showCELRulesTree filters the tree by the text of CEL validation rules and their messages.
+// Schemas are resolved off the UI goroutine, since documents may be fetched, the tree is replaced, when it's done.
+func showCELRulesTree(uiState *UIState, treeView *tview.TreeView, searchTerm string) {
+	searchTerm = strings.ToLower(searchTerm)
+	targets := make([]*TreeData, 0, len(uiState.treeLinks.Nodes))
+	for _, node := range uiState.treeLinks.Nodes {
+		if data, err := extractTreeData(node); err == nil {
+			targets = append(targets, data)
+		}
+	}
+	setStatusHint(uiState, "searching CEL rules...")
+
+	go func() {
+		matched, errs := findCELMatches(targets, searchTerm, uiState.schemaResolver.ResolveField)
+		uiState.app.QueueUpdateDraw(func() {
+			showMatchingTree(uiState, treeView, func(_ *tview.TreeNode, data *TreeData) bool {
+				return data.IsNodeType(nodeTypeResource, nodeTypeField) && matched[getPathKey(*data.gvr, data.path)]
+			})
+			reportResolveErrors(uiState, fmt.Sprintf("CEL rules: %d matches", len(matched)), errs)
+		})
+	}()
+}
+
+// findCELMatches resolves schemas of resources and fields, and returns keys (see getPathKey) of the ones,
+// that have CEL rules containing the term. Errors are returned once per group-version.
+func findCELMatches(
+	targets []*TreeData,
+	term string,
+	resolve func(gvr schema.GroupVersionResource, path string) (*FieldSchema, error),
+) (map[string]bool, []error) {
+	matched := make(map[string]bool)
+	var errs []error
+	failed := make(map[schema.GroupVersion]bool)
+	for _, data := range targets {
+		if !data.IsNodeType(nodeTypeResource, nodeTypeField) || data.gvr == nil {
+			continue
+		}
+		fs, err := resolve(*data.gvr, data.path)
+		if err != nil {
+			if gv := data.gvr.GroupVersion(); !failed[gv] {
+				failed[gv] = true
+				errs = append(errs, fmt.Errorf("%s: %w", data.path, err))
+			}
+			continue
+		}
+		validations := getCELValidations(fs)
+		for i := range validations {
+			if validations[i].contains(term) {
+				matched[getPathKey(*data.gvr, data.path)] = true
+				break
+			}
+		}
+	}
+	return matched, errs
+}
+
+// reportResolveErrors shows the result of a filter in the status line, or the first error of schema resolving
+func reportResolveErrors(uiState *UIState, result string, errs []error) {
+	switch len(errs) {
+	case 0:
+		setStatus(uiState, result)
+	case 1:
+		setStatusError(uiState, fmt.Errorf("%s, %w", result, errs[0]))
+	default:
+		setStatusError(uiState, fmt.Errorf("%s, %w (and %d more group-versions failed)", result, errs[0], len(errs)-1))
+	}
+}
+
+// showUsagesTree filters the tree by resources and fields using a definition
+func showUsagesTree(uiState *UIState, treeView *tview.TreeView, usages []TypeUsage) {
+	used := make(map[string]bool, len(usages))
+	for _, usage := range usages {
+		used[getPathKey(usage.GVR, usage.Path)] = true
+	}
+	showMatchingTree(uiState, treeView, func(_ *tview.TreeNode, data *TreeData) bool {
+		return data.IsNodeType(nodeTypeResource, nodeTypeField) && used[getPathKey(*data.gvr, data.path)]
+	})
+}
+
+// showDeprecatedTree filters the tree by deprecated fields and resources,
+// resources are marked when the tree is populated, see loadDeprecatedResources
+func showDeprecatedTree(uiState *UIState, treeView *tview.TreeView) {
+	showMatchingTree(uiState, treeView, func(_ *tview.TreeNode, data *TreeData) bool {
+		return data.meta.isDeprecated()
+	})
+}
@@ -0,0 +1,77 @@
+package apidocs
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const pageMessages = "messages"
+
+// setStatus shows a message in the status line with a timestamp, it is kept until the next command,
+// and it's added to the message log
+func setStatus(uiState *UIState, message string) {
+	m := uiState.messages.Add(time.Now(), message, false)
+	uiState.statusLine.SetText(tview.Escape(m.String()))
+}
+
+func setStatusError(uiState *UIState, err error) {
+	m := uiState.messages.Add(time.Now(), err.Error(), true)
+	uiState.statusLine.SetText(theme.colorize(theme.alert, tview.Escape(m.String())))
+}
+
+// setStatusHint shows a transient text, e.g. completion candidates, it's not added to the message log
+func setStatusHint(uiState *UIState, hint string) {
+	uiState.statusLine.SetText(tview.Escape(hint))
+}
+
+func clearStatus(uiState *UIState) {
+	uiState.statusLine.Clear()
+}
+
+// reportError shows a runtime error of a handler in the status line, and writes it to the debug log, nil is ignored
+func reportError(uiState *UIState, source string, err error) {
+	if err == nil {
+		return
+	}
+	slog.Debug(source, slog.String("error", err.Error()))
+	setStatusError(uiState, err)
+}
+
+// showMessages lists status messages and errors, the latest ones at the bottom
+func showMessages(uiState *UIState) {
+	view := tview.NewTextView()
+	view.SetDynamicColors(true)
+	view.SetScrollable(true)
+	view.SetWrap(true)
+	view.SetBorder(true)
+	view.SetTitle(fmt.Sprintf("Messages (%s: close)", uiState.keymap.KeysFor(actionClose)))
+
+	entries := uiState.messages.Entries()
+	lines := make([]string, 0, len(entries))
+	for _, m := range entries {
+		line := tview.Escape(m.String())
+		if m.IsError {
+			line = theme.colorize(theme.alert, line)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "(no messages yet)")
+	}
+	view.SetText(strings.Join(lines, "\n"))
+	view.ScrollToEnd()
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if uiState.keymap.Matches(actionStepBack, event) || uiState.keymap.Matches(actionClose, event) {
+			hideModal(uiState, pageMessages)
+			return nil
+		}
+		return event
+	})
+
+	showModal(uiState, pageMessages, view, 120, min(len(lines), 30)+2)
+}
@@ -0,0 +1,86 @@
+package apidocs
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// celValidation is an entry of the x-kubernetes-validations extension
+type celValidation struct {
+	rule              string
+	message           string
+	messageExpression string
+	reason            string
+	fieldPath         string
+}
+
+func getCELValidations(fs *FieldSchema) []celValidation {
+	var result []celValidation
+	for _, s := range []*spec.Schema{fs.Declared, fs.Resolved} {
+		if s == nil {
+			continue
+		}
+		values, ok := s.Extensions["x-kubernetes-validations"].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, v := range values {
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			validation := celValidation{}
+			validation.rule, _ = m["rule"].(string)
+			validation.message, _ = m["message"].(string)
+			validation.messageExpression, _ = m["messageExpression"].(string)
+			validation.reason, _ = m["reason"].(string)
+			validation.fieldPath, _ = m["fieldPath"].(string)
+			result = append(result, validation)
+		}
+		// the declared and resolved schemas are the same for inline (CRD) schemas
+		if fs.Declared == fs.Resolved {
+			break
+		}
+	}
+	return result
+}
+
+func (v *celValidation) contains(term string) bool {
+	for _, s := range []string{v.rule, v.message, v.messageExpression} {
+		if strings.Contains(strings.ToLower(s), term) {
+			return true
+		}
+	}
+	return false
+}
+
+func getCELValidationsDescription(fs *FieldSchema) string {
+	validations := getCELValidations(fs)
+	if len(validations) == 0 {
+		return ""
+	}
+	sb := strings.Builder{}
+	sb.WriteString("VALIDATIONS (CEL):\n")
+	for i := range validations {
+		v := &validations[i]
+		sb.WriteString(fmt.Sprintf("  rule:              %s\n", v.rule))
+		if v.message != "" {
+			sb.WriteString(fmt.Sprintf("  message:           %s\n", v.message))
+		}
+		if v.messageExpression != "" {
+			sb.WriteString(fmt.Sprintf("  messageExpression: %s\n", v.messageExpression))
+		}
+		if v.reason != "" {
+			sb.WriteString(fmt.Sprintf("  reason:            %s\n", v.reason))
+		}
+		if v.fieldPath != "" {
+			sb.WriteString(fmt.Sprintf("  fieldPath:         %s\n", v.fieldPath))
+		}
+		if i < len(validations)-1 {
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,75 @@
+package apidocs
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestGetCELValidations(t *testing.T) {
+	doc := newSchemaDocument(loadTestOpenAPIDocument(t))
+
+	fs, err := resolveFieldSchema(doc, testDeploymentGVK, []string{"spec"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	validations := getCELValidations(fs)
+	if len(validations) != 1 {
+		t.Fatalf("Expected 1 validation, got %+v", validations)
+	}
+	v := validations[0]
+	if v.rule != "self.replicas <= 10" || v.message != "At most 10 replicas" || v.reason != "FieldValueInvalid" {
+		t.Fatalf("Unexpected validation: %+v", v)
+	}
+	if !v.contains("replicas") || !v.contains("at most") || v.contains("containers") {
+		t.Fatalf("Unexpected matching of %+v", v)
+	}
+
+	// a field without rules
+	fs, err = resolveFieldSchema(doc, testDeploymentGVK, []string{"spec", "replicas"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if validations := getCELValidations(fs); len(validations) != 0 {
+		t.Fatalf("Expected no validations, got %+v", validations)
+	}
+}
+
+func TestFindCELMatches(t *testing.T) {
+	doc := newSchemaDocument(loadTestOpenAPIDocument(t))
+	calls := 0
+	resolve := func(gvr schema.GroupVersionResource, path string) (*FieldSchema, error) {
+		calls++
+		if gvr.Group != "apps" {
+			return nil, fmt.Errorf("couldn't find openapi v3 document for %q", gvr.GroupVersion())
+		}
+		return resolveFieldSchema(doc, testDeploymentGVK, strings.Split(path, ".")[1:])
+	}
+	podsGVR := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	targets := []*TreeData{
+		{nodeType: nodeTypeRoot},
+		{nodeType: nodeTypeResource, gvr: &testDeploymentGVR, path: "deployments"},
+		{nodeType: nodeTypeField, gvr: &testDeploymentGVR, path: "deployments.spec"},
+		{nodeType: nodeTypeField, gvr: &testDeploymentGVR, path: "deployments.spec.replicas"},
+		{nodeType: nodeTypeResource, gvr: &podsGVR, path: "pods"},
+		{nodeType: nodeTypeField, gvr: &podsGVR, path: "pods.spec"},
+	}
+
+	matched, errs := findCELMatches(targets, "at most", resolve)
+	if len(matched) != 1 || !matched[getPathKey(testDeploymentGVR, "deployments.spec")] {
+		t.Fatalf("Unexpected matches: %v", matched)
+	}
+	// the failing group-version is reported once
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "pods") {
+		t.Fatalf("Unexpected errors: %v", errs)
+	}
+	if calls != 5 {
+		t.Fatalf("Expected 5 resolved schemas, got %d", calls)
+	}
+
+	if matched, _ := findCELMatches(targets, "no such rule", resolve); len(matched) != 0 {
+		t.Fatalf("Unexpected matches: %v", matched)
+	}
+}